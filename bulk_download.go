@@ -0,0 +1,355 @@
+package odp
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DownloadBulkFileResume continues a bulk file download from byte offset
+// resumeFrom, writing the remaining bytes to w at that same offset. Pair it
+// with PartialDownloadSize to pick up an interrupted download:
+//
+//	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0o644)
+//	from, _ := odp.PartialDownloadSize(path)
+//	err := client.DownloadBulkFileResume(ctx, uri, f, from)
+//
+// A resumeFrom of zero downloads the whole file. Otherwise the server must
+// answer the range request with 206 Partial Content; if it returns the full
+// file instead, nothing is written and an error is returned. A partial file
+// that is already complete (the server reports 416 with a matching length)
+// returns nil without writing.
+func (c *Client) DownloadBulkFileResume(ctx context.Context, fileDownloadURI string, w io.WriterAt, resumeFrom int64) error {
+	if err := c.validateFileDownloadURI(fileDownloadURI); err != nil {
+		return err
+	}
+	if w == nil {
+		return fmt.Errorf("writer is required")
+	}
+	if resumeFrom < 0 {
+		return fmt.Errorf("resumeFrom must be >= 0, got %d", resumeFrom)
+	}
+	return c.streamDownloadRange(ctx, fileDownloadURI, io.NewOffsetWriter(w, resumeFrom), resumeFrom, -1, nil)
+}
+
+// GetBulkFileURL returns the signed download URL a bulk file redirects to,
+// without downloading it, for handing to an external downloader such as
+// aria2 or curl. fileName is the file's name within the product as the
+// product listing gives it, e.g. "ipg250916.zip", or with its year
+// directory, "2025/ipg250916.zip". The URL is signed and expires, so fetch
+// it just before use. Anything other than a redirect with a Location is an
+// error.
+func (c *Client) GetBulkFileURL(ctx context.Context, productID, fileName string) (string, error) {
+	if productID == "" || fileName == "" {
+		return "", fmt.Errorf("productID and fileName are required")
+	}
+	segments := []string{url.PathEscape(productID)}
+	for _, seg := range strings.Split(fileName, "/") {
+		segments = append(segments, url.PathEscape(seg))
+	}
+	uri := c.config.BaseURL + "/api/v1/datasets/products/files/" + strings.Join(segments, "/")
+
+	// Same client, but hand back the redirect instead of following it.
+	noRedirect := *c.httpClient
+	noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	var location string
+	err := c.retryLoop(ctx, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+		if err != nil {
+			return fmt.Errorf("creating request: %w", err)
+		}
+		req.Header.Set("User-Agent", c.config.UserAgent)
+		if c.config.APIKey != "" {
+			req.Header.Set("X-API-Key", c.config.APIKey)
+		}
+		resp, err := noRedirect.Do(req)
+		if err != nil {
+			return err
+		}
+		defer drainClose(resp.Body)
+		switch {
+		case resp.StatusCode >= 300 && resp.StatusCode < 400:
+			loc, err := resp.Location()
+			if err != nil {
+				return fmt.Errorf("bulk file %s/%s: redirect (status %d) without a Location", productID, fileName, resp.StatusCode)
+			}
+			location = loc.String()
+			return nil
+		case resp.StatusCode >= 200 && resp.StatusCode < 300:
+			return fmt.Errorf("bulk file %s/%s: expected a redirect to the download URL, got status %d", productID, fileName, resp.StatusCode)
+		default:
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+			return checkResponseStatus(resp.StatusCode, body, resp)
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+	return location, nil
+}
+
+// maxParallelSegments bounds the concurrent range requests of
+// DownloadBulkFileParallel, however many segments the caller asks for.
+const maxParallelSegments = 8
+
+// DownloadBulkFileParallel downloads a bulk file as segments byte ranges
+// fetched concurrently (at most maxParallelSegments at a time), each written
+// to w at its own offset. w must accept concurrent WriteAt calls, as
+// *os.File does.
+//
+// A HEAD request first checks that the server advertises
+// "Accept-Ranges: bytes" and a Content-Length. When it does not, or the
+// file is smaller than one byte per segment, the file is downloaded as a
+// single stream instead. Each segment's connection setup is retried like
+// any download; the first segment that fails cancels the rest and its error
+// is returned, leaving w partially written.
+func (c *Client) DownloadBulkFileParallel(ctx context.Context, fileDownloadURI string, w io.WriterAt, segments int) error {
+	if err := c.validateFileDownloadURI(fileDownloadURI); err != nil {
+		return err
+	}
+	if w == nil {
+		return fmt.Errorf("writer is required")
+	}
+	if segments < 1 {
+		return fmt.Errorf("segments must be >= 1, got %d", segments)
+	}
+	size, rangesOK := c.probeRangeSupport(ctx, fileDownloadURI)
+	if segments == 1 || !rangesOK || size < int64(segments) {
+		return c.streamDownload(ctx, fileDownloadURI, io.NewOffsetWriter(w, 0), nil)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	sem := make(chan struct{}, min(segments, maxParallelSegments))
+	segSize := (size + int64(segments) - 1) / int64(segments)
+	for start := int64(0); start < size; start += segSize {
+		end := min(start+segSize, size) - 1
+		wg.Go(func() {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-sem }()
+			if err := c.streamDownloadRange(ctx, fileDownloadURI, io.NewOffsetWriter(w, start), start, end, nil); err != nil {
+				once.Do(func() {
+					firstErr = fmt.Errorf("segment bytes %d-%d: %w", start, end, err)
+					cancel()
+				})
+			}
+		})
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// probeRangeSupport sends a HEAD for uri and reports the file size and
+// whether the server accepts byte ranges. Any failure reports no range
+// support; the caller's single-stream fallback then surfaces real errors
+// with the usual retry handling.
+func (c *Client) probeRangeSupport(ctx context.Context, uri string) (size int64, ok bool) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, uri, nil)
+	if err != nil {
+		return 0, false
+	}
+	req.Header.Set("User-Agent", c.config.UserAgent)
+	if c.config.APIKey != "" {
+		req.Header.Set("X-API-Key", c.config.APIKey)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, false
+	}
+	drainClose(resp.Body)
+	if resp.StatusCode != http.StatusOK || resp.ContentLength <= 0 {
+		return 0, false
+	}
+	for _, v := range resp.Header.Values("Accept-Ranges") {
+		for _, unit := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(unit), "bytes") {
+				return resp.ContentLength, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// BulkFileExpectation is what a downloaded bulk file must match. Zero
+// values skip the corresponding check.
+//
+// Size must be exact. The generated FileDataBag.FileSize is a float32 and
+// is rounded for files over 16 MiB, so take the size from the raw product
+// JSON rather than converting that field.
+type BulkFileExpectation struct {
+	Size   int64  // byte count
+	MD5    string // hex digest, any case
+	SHA256 string // hex digest, any case
+}
+
+// IntegrityError reports a downloaded file that does not match its
+// BulkFileExpectation. The bytes have already been written to the caller's
+// writer; discard them.
+type IntegrityError struct {
+	Check string // "size", "md5" or "sha256"
+	Want  string
+	Got   string
+}
+
+func (e *IntegrityError) Error() string {
+	return fmt.Sprintf("bulk file %s mismatch: got %s, want %s", e.Check, e.Got, e.Want)
+}
+
+// DownloadBulkFileVerified is DownloadBulkFileWithProgress with integrity
+// checks: the byte count and any requested digests are computed while the
+// file streams to w and compared with expect once it completes. A mismatch
+// returns an *IntegrityError, so a pipeline can tell a truncated or corrupt
+// ZIP apart from a transport failure before attempting extraction.
+func (c *Client) DownloadBulkFileVerified(ctx context.Context, fileDownloadURI string, w io.Writer, expect BulkFileExpectation, progress func(bytesComplete int64, bytesTotal int64)) error {
+	if err := c.validateFileDownloadURI(fileDownloadURI); err != nil {
+		return err
+	}
+	if expect.Size < 0 {
+		return fmt.Errorf("expected size must be >= 0, got %d", expect.Size)
+	}
+	cw := &countingWriter{}
+	writers := []io.Writer{w, cw}
+	var md5Hash, sha256Hash hash.Hash
+	if expect.MD5 != "" {
+		md5Hash = md5.New()
+		writers = append(writers, md5Hash)
+	}
+	if expect.SHA256 != "" {
+		sha256Hash = sha256.New()
+		writers = append(writers, sha256Hash)
+	}
+	if err := c.streamDownload(ctx, fileDownloadURI, io.MultiWriter(writers...), progress); err != nil {
+		return err
+	}
+
+	if expect.Size > 0 && cw.n != expect.Size {
+		return &IntegrityError{Check: "size", Want: strconv.FormatInt(expect.Size, 10), Got: strconv.FormatInt(cw.n, 10)}
+	}
+	if md5Hash != nil {
+		if got := hex.EncodeToString(md5Hash.Sum(nil)); !strings.EqualFold(got, strings.TrimSpace(expect.MD5)) {
+			return &IntegrityError{Check: "md5", Want: expect.MD5, Got: got}
+		}
+	}
+	if sha256Hash != nil {
+		if got := hex.EncodeToString(sha256Hash.Sum(nil)); !strings.EqualFold(got, strings.TrimSpace(expect.SHA256)) {
+			return &IntegrityError{Check: "sha256", Want: expect.SHA256, Got: got}
+		}
+	}
+	return nil
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// DownloadBulkFileToPath downloads a bulk file to destPath, reporting
+// progress like DownloadBulkFileWithProgress (progress may be nil). The file
+// is written to a temporary file in destPath's directory and renamed into
+// place only once the download completes, so destPath never holds a partial
+// file; on failure the temporary file is removed and an existing destPath is
+// left untouched.
+func (c *Client) DownloadBulkFileToPath(ctx context.Context, fileDownloadURI, destPath string, progress func(bytesComplete int64, bytesTotal int64)) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(destPath), "."+filepath.Base(destPath)+".*.part")
+	if err != nil {
+		return fmt.Errorf("creating temporary file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if err = c.DownloadBulkFileWithProgress(ctx, fileDownloadURI, tmp, progress); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("closing temporary file: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), destPath); err != nil {
+		return fmt.Errorf("moving download into place: %w", err)
+	}
+	return nil
+}
+
+// PartialDownloadSize returns the size of the file at path, the offset to
+// pass to DownloadBulkFileResume. A missing file has size zero.
+func PartialDownloadSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if !info.Mode().IsRegular() {
+		return 0, fmt.Errorf("%s is not a regular file", path)
+	}
+	return info.Size(), nil
+}
+
+// contentRangeStart returns the first byte position of a
+// "bytes start-end/size" Content-Range header.
+func contentRangeStart(v string) (int64, bool) {
+	spec, ok := strings.CutPrefix(strings.TrimSpace(v), "bytes ")
+	if !ok {
+		return 0, false
+	}
+	start, _, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(start), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// contentRangeSize returns the complete length from a Content-Range header,
+// either "bytes start-end/size" or the "bytes */size" form sent with 416.
+func contentRangeSize(v string) (int64, bool) {
+	_, size, ok := strings.Cut(v, "/")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(size), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
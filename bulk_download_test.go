@@ -0,0 +1,314 @@
+package odp
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const bulkFilePath = "/api/v1/datasets/products/files/PTGRXML/ipg240102.zip"
+
+// bulkRequests records the Range header of each GET the bulk file server
+// receives.
+type bulkRequests struct {
+	mu     sync.Mutex
+	ranges []string
+}
+
+func (b *bulkRequests) all() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.ranges...)
+}
+
+func (b *bulkRequests) last() string {
+	all := b.all()
+	if len(all) == 0 {
+		return ""
+	}
+	return all[len(all)-1]
+}
+
+// newBulkFileServer serves content at bulkFilePath. With ranges it behaves
+// like a range-capable file server (Accept-Ranges, 206, 416); without, it
+// ignores Range and always sends the whole file.
+func newBulkFileServer(t *testing.T, content string, ranges bool) (*httptest.Server, *bulkRequests) {
+	t.Helper()
+	rec := &bulkRequests{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != bulkFilePath {
+			w.Header().Del("Content-Type")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodGet {
+			rec.mu.Lock()
+			rec.ranges = append(rec.ranges, r.Header.Get("Range"))
+			rec.mu.Unlock()
+		}
+		if ranges {
+			http.ServeContent(w, r, "ipg240102.zip", time.Time{}, strings.NewReader(content))
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		_, _ = w.Write([]byte(content))
+	}))
+	t.Cleanup(server.Close)
+	return server, rec
+}
+
+func newBulkTestClient(t *testing.T, baseURL string) *Client {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.BaseURL = baseURL
+	cfg.APIKey = "test-key"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func TestDownloadBulkFileResume(t *testing.T) {
+	const content = "0123456789abcdefghij"
+	server, rec := newBulkFileServer(t, content, true)
+	client := newBulkTestClient(t, server.URL)
+
+	path := filepath.Join(t.TempDir(), "ipg240102.zip")
+	if err := os.WriteFile(path, []byte(content[:8]), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	from, err := PartialDownloadSize(path)
+	if err != nil || from != 8 {
+		t.Fatalf("PartialDownloadSize = %d, %v; want 8", from, err)
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.DownloadBulkFileResume(context.Background(), server.URL+bulkFilePath, f, from)
+	_ = f.Close()
+	if err != nil {
+		t.Fatalf("DownloadBulkFileResume: %v", err)
+	}
+	if got := rec.last(); got != "bytes=8-" {
+		t.Errorf("Range = %q, want bytes=8-", got)
+	}
+	got, _ := os.ReadFile(path)
+	if string(got) != content {
+		t.Errorf("file = %q, want %q", got, content)
+	}
+
+	// Resuming a complete file is a no-op.
+	f, _ = os.OpenFile(path, os.O_WRONLY, 0)
+	defer f.Close()
+	if err := client.DownloadBulkFileResume(context.Background(), server.URL+bulkFilePath, f, int64(len(content))); err != nil {
+		t.Errorf("resume of complete file: %v", err)
+	}
+}
+
+func TestDownloadBulkFileResume_RangeIgnored(t *testing.T) {
+	server, _ := newBulkFileServer(t, "0123456789", false)
+	client := newBulkTestClient(t, server.URL)
+
+	path := filepath.Join(t.TempDir(), "partial.zip")
+	if err := os.WriteFile(path, []byte("01234"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	err = client.DownloadBulkFileResume(context.Background(), server.URL+bulkFilePath, f, 5)
+	if err == nil || !strings.Contains(err.Error(), "does not support range requests") {
+		t.Fatalf("err = %v, want a range-not-supported error", err)
+	}
+	got, _ := os.ReadFile(path)
+	if string(got) != "01234" {
+		t.Errorf("partial file modified: %q", got)
+	}
+}
+
+func TestPartialDownloadSize_Missing(t *testing.T) {
+	n, err := PartialDownloadSize(filepath.Join(t.TempDir(), "absent.zip"))
+	if err != nil || n != 0 {
+		t.Errorf("PartialDownloadSize(missing) = %d, %v; want 0, nil", n, err)
+	}
+}
+
+func TestDownloadBulkFileToPath(t *testing.T) {
+	const content = "PK\x03\x04 bulk file body"
+	server, _ := newBulkFileServer(t, content, false)
+	client := newBulkTestClient(t, server.URL)
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	dest := filepath.Join(dir, "ipg240102.zip")
+	var last int64
+	err := client.DownloadBulkFileToPath(ctx, server.URL+bulkFilePath, dest, func(done, _ int64) { last = done })
+	if err != nil {
+		t.Fatalf("DownloadBulkFileToPath: %v", err)
+	}
+	if got, _ := os.ReadFile(dest); string(got) != content {
+		t.Errorf("file = %q, want %q", got, content)
+	}
+	if last != int64(len(content)) {
+		t.Errorf("last progress = %d, want %d", last, len(content))
+	}
+
+	// A failed download leaves an existing file alone and no temporary file.
+	err = client.DownloadBulkFileToPath(ctx, server.URL+"/api/v1/datasets/products/files/PTGRXML/missing.zip", dest, nil)
+	if err == nil {
+		t.Fatal("missing file should fail")
+	}
+	if got, _ := os.ReadFile(dest); string(got) != content {
+		t.Errorf("existing file changed to %q", got)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("directory holds %d entries after failure, want 1", len(entries))
+	}
+}
+
+func TestDownloadBulkFileVerified(t *testing.T) {
+	const content = "PK\x03\x04 pretend zip bytes"
+	server, _ := newBulkFileServer(t, content, false)
+	client := newBulkTestClient(t, server.URL)
+	uri := server.URL + bulkFilePath
+
+	md5Sum := md5.Sum([]byte(content))
+	shaSum := sha256.Sum256([]byte(content))
+	good := BulkFileExpectation{
+		Size:   int64(len(content)),
+		MD5:    hex.EncodeToString(md5Sum[:]),
+		SHA256: strings.ToUpper(hex.EncodeToString(shaSum[:])),
+	}
+
+	var buf bytes.Buffer
+	if err := client.DownloadBulkFileVerified(context.Background(), uri, &buf, good, nil); err != nil {
+		t.Fatalf("DownloadBulkFileVerified: %v", err)
+	}
+	if buf.String() != content {
+		t.Errorf("written = %q, want %q", buf.String(), content)
+	}
+
+	tests := []struct {
+		name   string
+		expect BulkFileExpectation
+		check  string
+	}{
+		{"size", BulkFileExpectation{Size: int64(len(content)) + 1}, "size"},
+		{"md5", BulkFileExpectation{MD5: strings.Repeat("0", 32)}, "md5"},
+		{"sha256", BulkFileExpectation{Size: good.Size, SHA256: strings.Repeat("0", 64)}, "sha256"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := client.DownloadBulkFileVerified(context.Background(), uri, &bytes.Buffer{}, tt.expect, nil)
+			var ie *IntegrityError
+			if !errors.As(err, &ie) || ie.Check != tt.check {
+				t.Fatalf("err = %v, want IntegrityError on %s", err, tt.check)
+			}
+		})
+	}
+}
+
+func TestDownloadBulkFileParallel(t *testing.T) {
+	content := strings.Repeat("0123456789abcdef", 64) + "tail"
+	tests := []struct {
+		name       string
+		ranges     bool
+		wantRanges int // GET requests carrying a Range header
+	}{
+		{"segmented", true, 4},
+		{"no Accept-Ranges falls back to one stream", false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, rec := newBulkFileServer(t, content, tt.ranges)
+			client := newBulkTestClient(t, server.URL)
+
+			f, err := os.Create(filepath.Join(t.TempDir(), "out.zip"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			if err := client.DownloadBulkFileParallel(context.Background(), server.URL+bulkFilePath, f, 4); err != nil {
+				t.Fatalf("DownloadBulkFileParallel: %v", err)
+			}
+			got, _ := os.ReadFile(f.Name())
+			if string(got) != content {
+				t.Errorf("file differs from source: %d bytes, want %d", len(got), len(content))
+			}
+
+			ranged := 0
+			for _, r := range rec.all() {
+				if r != "" {
+					ranged++
+				}
+			}
+			if ranged != tt.wantRanges || len(rec.all()) != max(tt.wantRanges, 1) {
+				t.Errorf("GET ranges = %q, want %d ranged requests", rec.all(), tt.wantRanges)
+			}
+		})
+	}
+}
+
+func TestGetBulkFileURL(t *testing.T) {
+	const signed = "https://data.uspto.gov/files/PTGRXML/2025/ipg250916.zip?Expires=1758628085&Signature=test"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/datasets/products/files/PTGRXML/2025/ipg250916.zip":
+			if r.Header.Get("X-API-Key") != "test-key" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			http.Redirect(w, r, signed, http.StatusFound)
+		case "/api/v1/datasets/products/files/PTGRXML/ipg250923.zip":
+			// Served directly instead of redirected.
+			_, _ = w.Write([]byte("PK"))
+		case "/api/v1/datasets/products/files/PTGRXML/ipg250930.zip":
+			w.WriteHeader(http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := newBulkTestClient(t, server.URL)
+	ctx := context.Background()
+
+	got, err := client.GetBulkFileURL(ctx, "PTGRXML", "2025/ipg250916.zip")
+	if err != nil {
+		t.Fatalf("GetBulkFileURL: %v", err)
+	}
+	if got != signed {
+		t.Errorf("GetBulkFileURL = %q, want %q", got, signed)
+	}
+
+	if _, err := client.GetBulkFileURL(ctx, "PTGRXML", "ipg250923.zip"); err == nil || !strings.Contains(err.Error(), "expected a redirect") {
+		t.Errorf("direct response: err = %v, want an expected-redirect error", err)
+	}
+	if _, err := client.GetBulkFileURL(ctx, "PTGRXML", "ipg250930.zip"); err == nil || !strings.Contains(err.Error(), "without a Location") {
+		t.Errorf("redirect without Location: err = %v", err)
+	}
+	var apiErr *APIError
+	if _, err := client.GetBulkFileURL(ctx, "PTGRXML", "missing.zip"); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("missing file: err = %v, want a 404 APIError", err)
+	}
+	if _, err := client.GetBulkFileURL(ctx, "", "ipg250916.zip"); err == nil {
+		t.Error("empty productID should fail")
+	}
+}
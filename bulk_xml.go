@@ -0,0 +1,236 @@
+package odp
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExtractBulkZip calls fn for each file in the ZIP archive read from r,
+// which holds size bytes (an *os.File and its Stat().Size(), say). Each
+// entry is decompressed as fn reads it and closed when fn returns, so only
+// one entry is open at a time and none is held in memory by this function.
+// Directory entries are skipped. An error from fn stops the walk and is
+// returned as-is.
+func ExtractBulkZip(r io.ReaderAt, size int64, fn func(name string, content io.Reader) error) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return fmt.Errorf("opening bulk zip: %w", err)
+	}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		if err := extractZipEntry(f, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractZipEntry(f *zip.File, fn func(name string, content io.Reader) error) error {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+	return fn(f.Name, rc)
+}
+
+// xmlDeclaration starts each document of a concatenated bulk XML file; see
+// startsXMLDocument for which occurrences count.
+var xmlDeclaration = []byte("<?xml")
+
+// SplitConcatenatedXML parses a bulk XML file - grant or application
+// documents back to back, each with its own <?xml?> declaration and
+// DOCTYPE - yielding one XMLDocument at a time. Only the document being
+// parsed is held in memory.
+//
+// A document that does not parse is yielded as an error naming its position
+// and the scan moves on to the next one; a read error is yielded last. Stop
+// early by breaking out of the loop:
+//
+//	for doc, err := range odp.SplitConcatenatedXML(f) {
+//		if err != nil {
+//			log.Print(err)
+//			continue
+//		}
+//		fmt.Println(doc.GetTitle())
+//	}
+func SplitConcatenatedXML(r io.Reader) iter.Seq2[*XMLDocument, error] {
+	return func(yield func(*XMLDocument, error) bool) {
+		err := splitXMLDocuments(r, func(n int, data []byte) bool {
+			doc, err := ParseXML(data)
+			if err != nil {
+				return yield(nil, fmt.Errorf("document %d: %w", n, err))
+			}
+			return yield(doc, nil)
+		})
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// ParseConcatenatedXML is SplitConcatenatedXML with a callback: fn is called
+// with each document in turn. An error from fn, or a document that does not
+// parse, stops the scan and is returned.
+func ParseConcatenatedXML(r io.Reader, fn func(*XMLDocument) error) error {
+	for doc, err := range SplitConcatenatedXML(r) {
+		if err != nil {
+			return err
+		}
+		if err := fn(doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SplitBulkXMLFile writes each document of the concatenated bulk XML file
+// at srcPath to its own file in destDir, which is created if needed. Files
+// are named by publication number - the grant or pre-grant publication
+// number, e.g. 11646472.xml or 20210210819.xml - or by application number
+// when a document has no publication reference. Document bytes are copied
+// unchanged. A document that appears twice overwrites the earlier file.
+//
+// It returns the number of files written. A document that does not parse
+// or has no number stops the split; so does cancelling ctx.
+func SplitBulkXMLFile(ctx context.Context, srcPath, destDir string) (int, error) {
+	f, err := os.Open(srcPath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	var splitErr error
+	err = splitXMLDocuments(f, func(n int, data []byte) bool {
+		if splitErr = ctx.Err(); splitErr != nil {
+			return false
+		}
+		doc, err := ParseXML(data)
+		if err != nil {
+			splitErr = fmt.Errorf("document %d: %w", n, err)
+			return false
+		}
+		name := bulkDocumentName(doc)
+		if name == "" {
+			splitErr = fmt.Errorf("document %d: no publication or application number", n)
+			return false
+		}
+		if splitErr = os.WriteFile(filepath.Join(destDir, name+".xml"), data, 0o644); splitErr != nil {
+			return false
+		}
+		count++
+		return true
+	})
+	if err != nil {
+		return count, err
+	}
+	return count, splitErr
+}
+
+// bulkDocumentName is the file name stem SplitBulkXMLFile uses for doc.
+func bulkDocumentName(doc *XMLDocument) string {
+	b := doc.bibliography()
+	if b == nil {
+		return ""
+	}
+	for _, ref := range []*DocumentID{b.PublicationReference, b.ApplicationReference} {
+		if ref == nil {
+			continue
+		}
+		// Doc numbers are plain alphanumerics (11646472, D0987654, RE049123);
+		// anything else is dropped so the name stays inside destDir.
+		name := strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' || r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' {
+				return r
+			}
+			return -1
+		}, ref.DocNumber)
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// splitXMLDocuments cuts r at each XML declaration and calls fn with the
+// 1-based position and bytes of every non-blank document until fn returns
+// false. The slice is only valid during the call. It returns read errors
+// other than io.EOF.
+func splitXMLDocuments(r io.Reader, fn func(n int, data []byte) bool) error {
+	br := bufio.NewReader(r)
+	var buf bytes.Buffer
+	n := 0
+	// flush hands the buffered document to fn and reports whether to go on.
+	flush := func() bool {
+		defer buf.Reset()
+		if len(bytes.TrimSpace(buf.Bytes())) == 0 {
+			return true
+		}
+		n++
+		return fn(n, buf.Bytes())
+	}
+	for {
+		line, readErr := br.ReadBytes('\n')
+		// A declaration starts a new document; what came before it is the
+		// previous one.
+		start := 0
+		for off := 0; ; {
+			j := bytes.Index(line[off:], xmlDeclaration)
+			if j < 0 {
+				break
+			}
+			i := off + j
+			off = i + len(xmlDeclaration)
+			if !startsXMLDocument(line, i) {
+				continue
+			}
+			buf.Write(line[start:i])
+			if !flush() {
+				return nil
+			}
+			start = i
+		}
+		buf.Write(line[start:])
+		if errors.Is(readErr, io.EOF) {
+			flush()
+			return nil
+		}
+		if readErr != nil {
+			return fmt.Errorf("reading bulk XML: %w", readErr)
+		}
+	}
+}
+
+// startsXMLDocument reports whether the "<?xml" at line[i:] is the
+// declaration of a new document. It must be a real declaration - followed
+// by whitespace or "?>", so not "<?xml-stylesheet" - at the start of the
+// line. Bulk files put each declaration there; one directly after the
+// closing ">" of the previous document is accepted too, for files without
+// newlines between documents.
+func startsXMLDocument(line []byte, i int) bool {
+	rest := line[i+len(xmlDeclaration):]
+	if !bytes.HasPrefix(rest, []byte("?>")) && (len(rest) == 0 || !isXMLSpace(rest[0])) {
+		return false
+	}
+	before := bytes.TrimRight(line[:i], " \t\r\n")
+	return len(before) == 0 || before[len(before)-1] == '>'
+}
+
+func isXMLSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r' || b == '\n'
+}
@@ -0,0 +1,184 @@
+package odp
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// concatenatedXML is a bulk-style file: a grant and an application back to
+// back, each with its own declaration and DOCTYPE.
+const concatenatedXML = sampleGrantXML + "\n" + sampleApplicationXML + "\n"
+
+func TestExtractBulkZip(t *testing.T) {
+	var zbuf bytes.Buffer
+	zw := zip.NewWriter(&zbuf)
+	if _, err := zw.Create("ipg240102/"); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{
+		"ipg240102/ipg240102.xml": concatenatedXML,
+		"ipg240102/README.txt":    "bulk product notes",
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = io.WriteString(w, content)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	var docs []*XMLDocument
+	err := ExtractBulkZip(bytes.NewReader(zbuf.Bytes()), int64(zbuf.Len()), func(name string, content io.Reader) error {
+		names = append(names, name)
+		if !strings.HasSuffix(name, ".xml") {
+			return nil
+		}
+		return ParseConcatenatedXML(content, func(doc *XMLDocument) error {
+			docs = append(docs, doc)
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("ExtractBulkZip: %v", err)
+	}
+	if len(names) != 2 {
+		t.Errorf("entries = %v, want the two files and no directory", names)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("parsed %d documents, want 2", len(docs))
+	}
+	if docs[0].GetDocumentType() != DocumentTypeGrant || docs[1].GetDocumentType() != DocumentTypeApplication {
+		t.Errorf("document types = %v, %v; want grant then application", docs[0].GetDocumentType(), docs[1].GetDocumentType())
+	}
+
+	stop := errors.New("stop")
+	err = ExtractBulkZip(bytes.NewReader(zbuf.Bytes()), int64(zbuf.Len()), func(string, io.Reader) error { return stop })
+	if !errors.Is(err, stop) {
+		t.Errorf("callback error = %v, want it returned", err)
+	}
+}
+
+func TestParseConcatenatedXML_NoNewlines(t *testing.T) {
+	// Documents glued together on one line still split at the declaration.
+	in := strings.ReplaceAll(sampleGrantXML, "\n", "") + strings.ReplaceAll(sampleGrantXML, "\n", "")
+	n := 0
+	err := ParseConcatenatedXML(strings.NewReader(in), func(doc *XMLDocument) error {
+		n++
+		if doc.GetTitle() != "SYSTEM AND METHOD FOR ARTIFICIAL INTELLIGENCE" {
+			t.Errorf("title = %q", doc.GetTitle())
+		}
+		return nil
+	})
+	if err != nil || n != 2 {
+		t.Errorf("ParseConcatenatedXML = %d docs, %v; want 2, nil", n, err)
+	}
+}
+
+func TestParseConcatenatedXML_ProcessingInstructions(t *testing.T) {
+	// A stylesheet instruction and "<?xml" text inside a document are not
+	// declarations and must not split it.
+	grant := strings.Replace(sampleGrantXML, "\n", "\n<?xml-stylesheet type=\"text/xsl\" href=\"grant.xsl\"?>\n", 1)
+	grant = strings.Replace(grant, "</us-patent-grant>", "<!-- copied from <?xml version=\"1.0\"?> -->\n</us-patent-grant>", 1)
+	in := grant + "\n" + sampleApplicationXML + "\n"
+	var types []DocumentType
+	err := ParseConcatenatedXML(strings.NewReader(in), func(doc *XMLDocument) error {
+		types = append(types, doc.GetDocumentType())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ParseConcatenatedXML: %v", err)
+	}
+	if len(types) != 2 || types[0] != DocumentTypeGrant || types[1] != DocumentTypeApplication {
+		t.Errorf("documents = %v, want grant then application", types)
+	}
+}
+
+func TestParseConcatenatedXML_BadDocument(t *testing.T) {
+	in := sampleGrantXML + "\n" + invalidXML
+	n := 0
+	err := ParseConcatenatedXML(strings.NewReader(in), func(*XMLDocument) error {
+		n++
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "document 2") {
+		t.Errorf("err = %v, want a parse error naming document 2", err)
+	}
+	if n != 1 {
+		t.Errorf("callback ran %d times before the error, want 1", n)
+	}
+}
+
+func TestSplitConcatenatedXML(t *testing.T) {
+	// A broken document in the middle is reported and skipped.
+	in := sampleGrantXML + "\n" + malformedXML + "\n" + sampleApplicationXML
+	var types []DocumentType
+	var errs []error
+	for doc, err := range SplitConcatenatedXML(strings.NewReader(in)) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		types = append(types, doc.GetDocumentType())
+	}
+	if len(types) != 2 || types[0] != DocumentTypeGrant || types[1] != DocumentTypeApplication {
+		t.Errorf("documents = %v, want grant then application", types)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "document 2") {
+		t.Errorf("errors = %v, want one naming document 2", errs)
+	}
+
+	// Breaking out stops the scan.
+	n := 0
+	for range SplitConcatenatedXML(strings.NewReader(concatenatedXML)) {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("iterations after break = %d, want 1", n)
+	}
+}
+
+func TestSplitBulkXMLFile(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "ipg240102.xml")
+	if err := os.WriteFile(src, []byte(concatenatedXML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dest := filepath.Join(t.TempDir(), "split")
+
+	n, err := SplitBulkXMLFile(context.Background(), src, dest)
+	if err != nil || n != 2 {
+		t.Fatalf("SplitBulkXMLFile = %d, %v; want 2, nil", n, err)
+	}
+	entries, err := os.ReadDir(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	if want := []string{"11234567.xml", "20210210819.xml"}; !slices.Equal(names, want) {
+		t.Errorf("files = %v, want %v", names, want)
+	}
+	got, _ := os.ReadFile(filepath.Join(dest, "11234567.xml"))
+	if doc, err := ParseXML(got); err != nil || doc.GetDocumentType() != DocumentTypeGrant {
+		t.Errorf("11234567.xml does not hold the grant: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := SplitBulkXMLFile(ctx, src, dest); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled split: err = %v, want context.Canceled", err)
+	}
+}
@@ -92,3 +92,20 @@ func TestExtractClaims_PreservesClaimRef_PolyPlus(t *testing.T) {
 		}
 	}
 }
+
+func TestDrawingDescriptions_RealGrant(t *testing.T) {
+	doc, err := ParseGrantXML(readFixture(t, "grant_us11646472b2_17248024.xml"))
+	if err != nil {
+		t.Fatalf("parse grant XML: %v", err)
+	}
+	figs := doc.GetDescription().DrawingDescriptions()
+	if len(figs) != 5 {
+		t.Fatalf("want 5 drawing descriptions, got %d: %q", len(figs), figs)
+	}
+	if !strings.HasPrefix(figs[0], "FIG. 1 is a schematic illustration") {
+		t.Errorf("figs[0] = %q, want FIG. 1 label preserved", figs[0])
+	}
+	if !strings.HasPrefix(figs[3], "FIGS. 4-6 are plots") {
+		t.Errorf("figs[3] = %q, want FIGS. 4-6 label preserved", figs[3])
+	}
+}
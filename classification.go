@@ -0,0 +1,69 @@
+package odp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// cpcPattern matches a CPC symbol with all whitespace removed: section
+// letter, two-digit class, subclass letter, then an optional main group
+// and subgroup ("A61L2/22", "A61L2101/32", "A61L").
+var cpcPattern = regexp.MustCompile(`^([A-HY])(\d{2})([A-Z])(?:(\d{1,4})/(\d{1,6}))?$`)
+
+// ParseCPC splits a CPC classification symbol into its components. The API
+// pads the main group to four characters ("A61L   2/22"), but any spacing,
+// or none, is accepted; a subclass-level symbol such as "A61L" returns
+// empty group and subgroup. For "A61L   2/22" the result is "A", "61",
+// "L", "2", "22".
+func ParseCPC(symbol string) (section, class, subclass, group, subgroup string, err error) {
+	compact := strings.ToUpper(strings.Join(strings.Fields(symbol), ""))
+	m := cpcPattern.FindStringSubmatch(compact)
+	if m == nil {
+		return "", "", "", "", "", fmt.Errorf("invalid CPC symbol %q", symbol)
+	}
+	return m[1], m[2], m[3], m[4], m[5], nil
+}
+
+// FormatCPC joins CPC components into the symbol form the API uses, with
+// the main group right-aligned in four characters: "A", "61", "L", "2",
+// "22" gives "A61L   2/22". Without a group it returns the subclass symbol
+// ("A61L"); without a section it returns "".
+func FormatCPC(section, class, subclass, group, subgroup string) string {
+	section, class, subclass = strings.TrimSpace(section), strings.TrimSpace(class), strings.TrimSpace(subclass)
+	group, subgroup = strings.TrimSpace(group), strings.TrimSpace(subgroup)
+	if section == "" {
+		return ""
+	}
+	if group == "" || subgroup == "" {
+		return section + class + subclass
+	}
+	return fmt.Sprintf("%s%s%s%4s/%s", section, class, subclass, group, subgroup)
+}
+
+// formatUSPC converts a fixed-width USPC classification from grant XML -
+// three characters of class, three of subclass, then any subclass
+// extension - to class/subclass: "705  7" is "705/7", "4241851" is
+// "424/185.1" and "D14138" is "D14/138".
+func formatUSPC(raw string) string {
+	raw = strings.TrimRight(raw, " ")
+	if strings.TrimSpace(raw) == "" {
+		return ""
+	}
+	if len(raw) <= 3 {
+		return strings.ReplaceAll(raw, " ", "")
+	}
+	class := strings.ReplaceAll(raw[:3], " ", "")
+	rest := raw[3:]
+	subclass := strings.TrimSpace(rest)
+	if len(rest) > 3 {
+		subclass = strings.TrimSpace(rest[:3])
+		if ext := strings.TrimSpace(rest[3:]); ext != "" {
+			subclass += "." + ext
+		}
+	}
+	if subclass == "" {
+		return class
+	}
+	return class + "/" + subclass
+}
@@ -0,0 +1,81 @@
+package odp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCPC(t *testing.T) {
+	tests := []struct {
+		in                                        string
+		section, class, subclass, group, subgroup string
+	}{
+		{"A61L   2/22", "A", "61", "L", "2", "22"},
+		{"A61L2101/32", "A", "61", "L", "2101", "32"},
+		{" h01m  10/0525 ", "H", "01", "M", "10", "0525"},
+		{"Y10S 128/00", "Y", "10", "S", "128", "00"},
+		{"A61L", "A", "61", "L", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			section, class, subclass, group, subgroup, err := ParseCPC(tt.in)
+			if err != nil {
+				t.Fatalf("ParseCPC(%q): %v", tt.in, err)
+			}
+			got := [5]string{section, class, subclass, group, subgroup}
+			want := [5]string{tt.section, tt.class, tt.subclass, tt.group, tt.subgroup}
+			if got != want {
+				t.Errorf("ParseCPC(%q) = %q, want %q", tt.in, got, want)
+			}
+		})
+	}
+
+	for _, bad := range []string{"", "61L 2/22", "A61L 2", "Z61L 2/22", "A61L 2/22/1"} {
+		if _, _, _, _, _, err := ParseCPC(bad); err == nil {
+			t.Errorf("ParseCPC(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestFormatCPC(t *testing.T) {
+	tests := []struct {
+		parts [5]string
+		want  string
+	}{
+		{[5]string{"A", "61", "L", "2", "22"}, "A61L   2/22"},
+		{[5]string{"H", "01", "M", "10", "0525"}, "H01M  10/0525"},
+		{[5]string{"A", "61", "L", "", ""}, "A61L"},
+		{[5]string{" B ", "60", "L", " 53 ", "20"}, "B60L  53/20"},
+		{[5]string{}, ""},
+	}
+	for _, tt := range tests {
+		p := tt.parts
+		got := FormatCPC(p[0], p[1], p[2], p[3], p[4])
+		if got != tt.want {
+			t.Errorf("FormatCPC(%q) = %q, want %q", p, got, tt.want)
+		}
+		if got != "" {
+			// The formatted symbol parses back to the same parts.
+			compact := strings.ReplaceAll(strings.Join(p[:], ""), " ", "")
+			if s, c, sc, g, sg, err := ParseCPC(got); err != nil || s+c+sc+g+sg != compact {
+				t.Errorf("ParseCPC(%q) = %s %s %s %s %s, %v", got, s, c, sc, g, sg, err)
+			}
+		}
+	}
+}
+
+func TestFormatUSPC(t *testing.T) {
+	tests := map[string]string{
+		"705  7":  "705/7",
+		"4241851": "424/185.1",
+		"D14138":  "D14/138",
+		"D 6552":  "D6/552",
+		"PLT263":  "PLT/263",
+		"   ":     "",
+	}
+	for raw, want := range tests {
+		if got := formatUSPC(raw); got != want {
+			t.Errorf("formatUSPC(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
@@ -5,13 +5,19 @@ package odp
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"iter"
+	"log/slog"
 	"math"
 	"math/rand/v2"
 	"net/http"
+	"net/url"
+	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/patent-dev/uspto-odp/generated"
@@ -30,25 +36,58 @@ const Version = "1.5.0"
 // "MyApp/2.3 uspto-odp/1.5".
 const DefaultUserAgent = "uspto-odp/" + Version + " (patent.dev; +https://github.com/patent-dev/uspto-odp)"
 
-// Client is the main USPTO ODP API client
+// Client is the main USPTO ODP API client. A Client is safe for concurrent
+// use by multiple goroutines, and should be shared rather than created per
+// request: its rate limiter, status-code cache, and patent number resolution
+// cache only work across calls that go through the same Client. The Config
+// passed to NewClient must not be modified afterwards.
 type Client struct {
 	config     *Config
 	httpClient *http.Client
 	generated  *generated.ClientWithResponses
 	oa         *oa.ClientWithResponses
 	tsdr       *tsdrgen.ClientWithResponses
+
+	// primaryURL and fallbackURL are the parsed BaseURL and FallbackBaseURL;
+	// fallbackURL is nil when no fallback is configured.
+	primaryURL  *url.URL
+	fallbackURL *url.URL
+
+	// statusDescriptions caches the status-code table for
+	// EnrichWithStatusDescriptions; nil until first loaded.
+	statusMu           sync.Mutex
+	statusDescriptions map[int]string
+	statusLoad         *statusCodeLoad // in-flight load, if any
+
+	// resolved caches patent number resolutions; nil unless
+	// Config.ResolveCacheTTL is positive.
+	resolved *resolveCache
 }
 
 // Config holds client configuration.
-// Note: Timeout applies to all APIs (ODP, OA, TSDR) uniformly. If TSDR document
-// downloads need a longer timeout, create a separate Client with a higher Timeout.
 type Config struct {
 	BaseURL    string
 	APIKey     string
 	UserAgent  string
 	MaxRetries int
 	RetryDelay time.Duration // base backoff between retries
-	Timeout    time.Duration // request timeout for the underlying http.Client
+
+	// Timeout bounds each HTTP attempt, body included, on every API (ODP,
+	// OA, TSDR) and download. It applies only when the context passed to a
+	// method has no deadline: give that context a deadline to allow a slow
+	// call such as a bulk file or XML download longer, without a second
+	// Client. A context deadline covers all of the call's retries together.
+	// Zero means no timeout.
+	Timeout time.Duration
+
+	// BackoffStrategy selects how the wait between retries grows. The zero
+	// value is BackoffExponential.
+	BackoffStrategy BackoffStrategy
+
+	// MaxRetryDelay caps the computed backoff between retries. Zero means no
+	// cap. It does not apply to waits requested by Retry-After, which are
+	// bounded by MaxRetryAfter instead.
+	MaxRetryDelay time.Duration
 
 	// MaxRetryAfter is the longest Retry-After the client will honor. If the
 	// server requests a longer wait, the resulting *APIError reports
@@ -56,6 +95,64 @@ type Config struct {
 	// DefaultMaxRetryAfter constant".
 	MaxRetryAfter time.Duration
 
+	// RequestsPerSecond, when positive, limits the client to that many
+	// outbound HTTP requests per second across all APIs and downloads,
+	// including retries. Burst is how many may be sent back to back before
+	// the limit applies (minimum 1). Zero leaves requests unthrottled.
+	RequestsPerSecond float64
+	Burst             int
+
+	// CaptureUnknownFields makes GetPatentMetaData decode the response a
+	// second time to fill MetaDataResponse.Extra with fields the generated
+	// types do not model yet. Off by default to save the extra decode.
+	CaptureUnknownFields bool
+
+	// ResolveCacheTTL, when positive, is how long the client remembers the
+	// application number a grant or publication number resolved to, so
+	// repeat calls with the same number skip the search. The cache holds at
+	// most 10000 resolutions, dropping expired ones, then the oldest, to
+	// make room. Zero or negative, the default, leaves it off. Failed
+	// resolutions are never cached.
+	ResolveCacheTTL time.Duration
+
+	// Transport is the http.RoundTripper every request is finally sent
+	// through; nil means http.DefaultTransport. Set it to instrument each
+	// HTTP attempt, e.g. otelhttp.NewTransport(http.DefaultTransport). The
+	// rate limiter, Logger and ResponseInspector sit in front of it.
+	Transport http.RoundTripper
+
+	// ObserveCall, when set, is called as each API call starts - a client
+	// method's request together with its retries and fallback attempt -
+	// for wrapping it in a tracing span without this package depending on
+	// a tracing library. The context it returns is used for the call's
+	// requests, so attempt-level spans from Transport nest under it; done,
+	// if non-nil, is called once with the call's endpoint, final status,
+	// attempt count and error. A method that makes several requests (one
+	// resolving a patent number, say) reports each as its own call. For a
+	// download the call ends when the response is accepted, before its
+	// body is copied. Nil costs nothing.
+	ObserveCall func(ctx context.Context) (_ context.Context, done func(CallInfo))
+
+	// Logger, when set, receives debug-level records of every HTTP request
+	// (method, host, path, status, latency) and of retry and fallback
+	// decisions (attempt, wait, error). Nil logs nothing.
+	Logger *slog.Logger
+
+	// ResponseInspector, when set, is called with every HTTP response the
+	// client receives - each retry and fallback attempt, API calls and
+	// downloads alike - before the client reads it, for logging headers
+	// such as rate-limit counters and request IDs. It gets a copy whose
+	// Body holds the first 64 KiB of the body; reading or closing it does
+	// not affect the client. It may be called from several goroutines at
+	// once and must not block.
+	ResponseInspector func(*http.Response)
+
+	// FallbackBaseURL is an optional mirror of the ODP API. When a request to
+	// BaseURL still fails with a retryable error after MaxRetries, it is sent
+	// once more to this host before the error is returned. Office Action,
+	// TSDR and bulk file downloads are never redirected.
+	FallbackBaseURL string
+
 	// OABaseURL is the host serving the Office Action APIs. Defaults to
 	// the ODP host (https://api.uspto.gov); override to point elsewhere.
 	OABaseURL string
@@ -65,16 +162,41 @@ type Config struct {
 	TSDRAPIKey  string // from https://account.uspto.gov/profile/api-manager
 }
 
+// BackoffStrategy is the schedule of waits between retries, based on
+// Config.RetryDelay.
+type BackoffStrategy int
+
+// Backoff strategies.
+const (
+	// BackoffExponential doubles the delay on each retry and adds up to 25%
+	// jitter: RetryDelay*2^n*(1+[0,0.25)).
+	BackoffExponential BackoffStrategy = iota
+	// BackoffFullJitter draws the delay uniformly from [0, RetryDelay*2^n),
+	// which spreads out clients that failed at the same moment.
+	BackoffFullJitter
+	// BackoffLinear grows the delay by RetryDelay on each retry:
+	// RetryDelay*(n+1).
+	BackoffLinear
+)
+
 // DefaultOABaseURL is the ODP host serving the Office Action APIs.
 const DefaultOABaseURL = "https://api.uspto.gov"
 
 // DefaultMaxRetryAfter is the cap applied when Config.MaxRetryAfter is zero.
 const DefaultMaxRetryAfter = 60 * time.Second
 
-// DefaultConfig returns default configuration
+// APIKeyEnv is the environment variable DefaultConfig reads the ODP API
+// key from.
+const APIKeyEnv = "USPTO_API_KEY"
+
+// DefaultConfig returns default configuration. APIKey is taken from the
+// USPTO_API_KEY environment variable when it is set; an APIKey set on the
+// returned Config, or with WithAPIKey, takes precedence. A Config built
+// from scratch, without DefaultConfig, does not read the environment.
 func DefaultConfig() *Config {
 	return &Config{
 		BaseURL:       "https://api.uspto.gov",
+		APIKey:        os.Getenv(APIKeyEnv),
 		UserAgent:     DefaultUserAgent,
 		MaxRetries:    3,
 		RetryDelay:    1 * time.Second,
@@ -84,6 +206,37 @@ func DefaultConfig() *Config {
 	}
 }
 
+// validateConfig rejects settings that would otherwise only fail, or
+// misbehave, on the first request.
+func validateConfig(config *Config) error {
+	u, err := url.Parse(config.BaseURL)
+	if err != nil {
+		return fmt.Errorf("invalid BaseURL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid BaseURL %q: want an http or https URL with a host", config.BaseURL)
+	}
+	if config.Timeout < 0 {
+		return fmt.Errorf("Timeout must be >= 0, got %v", config.Timeout)
+	}
+	if config.MaxRetries < 0 {
+		return fmt.Errorf("MaxRetries must be >= 0, got %d", config.MaxRetries)
+	}
+	if config.RetryDelay < 0 {
+		return fmt.Errorf("RetryDelay must be >= 0, got %v", config.RetryDelay)
+	}
+	if config.MaxRetryDelay < 0 {
+		return fmt.Errorf("MaxRetryDelay must be >= 0, got %v", config.MaxRetryDelay)
+	}
+	if config.MaxRetryAfter < 0 {
+		return fmt.Errorf("MaxRetryAfter must be >= 0, got %v", config.MaxRetryAfter)
+	}
+	if config.RequestsPerSecond < 0 {
+		return fmt.Errorf("RequestsPerSecond must be >= 0, got %v", config.RequestsPerSecond)
+	}
+	return nil
+}
+
 // NewClient creates a new USPTO ODP API client
 func NewClient(config *Config) (*Client, error) {
 	if config == nil {
@@ -94,12 +247,51 @@ func NewClient(config *Config) (*Client, error) {
 	cfg := *config
 	config = &cfg
 
-	httpClient := &http.Client{
-		Timeout: config.Timeout,
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	transport := config.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if config.ResponseInspector != nil {
+		transport = &inspectingTransport{base: transport, inspect: config.ResponseInspector}
+	}
+	if config.Logger != nil {
+		transport = &loggingTransport{base: transport, logger: config.Logger}
+	}
+	if config.RequestsPerSecond > 0 {
+		transport = &rateLimitedTransport{
+			base:    transport,
+			limiter: newRateLimiter(config.RequestsPerSecond, config.Burst),
+		}
+	}
+	if config.Timeout > 0 {
+		transport = &timeoutTransport{base: transport, timeout: config.Timeout}
+	}
+	if config.ObserveCall != nil {
+		transport = &observingTransport{base: transport}
+	}
+	httpClient := &http.Client{Transport: transport}
+
+	primaryURL, err := url.Parse(config.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid BaseURL: %w", err)
+	}
+	var fallbackURL *url.URL
+	if config.FallbackBaseURL != "" {
+		fallbackURL, err = url.Parse(config.FallbackBaseURL)
+		if err != nil || fallbackURL.Host == "" {
+			return nil, fmt.Errorf("invalid FallbackBaseURL %q", config.FallbackBaseURL)
+		}
 	}
 
 	// ODP and the OA APIs both authenticate with X-API-Key on api.uspto.gov.
-	odpEditor := func(_ context.Context, req *http.Request) error {
+	odpEditor := func(ctx context.Context, req *http.Request) error {
+		if fallbackURL != nil && isFallbackRoute(ctx) {
+			rebaseURL(req, primaryURL, fallbackURL)
+		}
 		req.Header.Set("User-Agent", config.UserAgent)
 		if config.APIKey != "" {
 			req.Header.Set("X-API-Key", config.APIKey)
@@ -137,10 +329,13 @@ func NewClient(config *Config) (*Client, error) {
 	}
 
 	client := &Client{
-		config:     config,
-		httpClient: httpClient,
-		generated:  genClient,
-		oa:         oaClient,
+		config:      config,
+		httpClient:  httpClient,
+		generated:   genClient,
+		oa:          oaClient,
+		primaryURL:  primaryURL,
+		fallbackURL: fallbackURL,
+		resolved:    newResolveCache(config.ResolveCacheTTL),
 	}
 
 	// TSDR client (optional, only initialized if TSDRAPIKey is set)
@@ -215,11 +410,89 @@ func truncatePreview(s string, maxLen int) string {
 	return s[:maxLen] + "..."
 }
 
-// retryableRequest wraps requests with retry logic, respecting context cancellation.
-func (c *Client) retryableRequest(ctx context.Context, fn func() error) error {
+// backoffDelay returns the wait before retry number attempt+1 under the
+// configured BackoffStrategy, capped at MaxRetryDelay when that is set.
+func (c *Client) backoffDelay(attempt int) time.Duration {
+	// RetryDelay is a time.Duration (nanoseconds under the hood); the float64
+	// round-trip stays in nanos, so the final time.Duration cast carries the
+	// right unit.
+	base := float64(c.config.RetryDelay)
+	var delay float64
+	switch c.config.BackoffStrategy {
+	case BackoffLinear:
+		delay = base * float64(attempt+1)
+	case BackoffFullJitter:
+		delay = rand.Float64() * base * math.Pow(2, float64(attempt))
+	default:
+		delay = base * math.Pow(2, float64(attempt))
+		delay += delay * 0.25 * rand.Float64()
+	}
+	if ceiling := float64(c.config.MaxRetryDelay); ceiling > 0 && delay > ceiling {
+		delay = ceiling
+	}
+	return time.Duration(delay)
+}
+
+// fallbackRouteKey marks a request context whose ODP requests go to
+// Config.FallbackBaseURL instead of BaseURL.
+type fallbackRouteKey struct{}
+
+func withFallbackRoute(ctx context.Context) context.Context {
+	return context.WithValue(ctx, fallbackRouteKey{}, true)
+}
+
+func isFallbackRoute(ctx context.Context) bool {
+	v, _ := ctx.Value(fallbackRouteKey{}).(bool)
+	return v
+}
+
+// rebaseURL moves req from the from base URL to the to base URL, keeping the
+// API path below the base and the query string.
+func rebaseURL(req *http.Request, from, to *url.URL) {
+	rel := strings.TrimPrefix(req.URL.Path, strings.TrimSuffix(from.Path, "/"))
+	req.URL.Scheme = to.Scheme
+	req.URL.Host = to.Host
+	req.URL.Path = strings.TrimSuffix(to.Path, "/") + rel
+	req.URL.RawPath = ""
+	req.Host = ""
+}
+
+// retryableRequest wraps requests to the ODP API with retry logic, respecting
+// context cancellation. fn must issue its request with the context it is
+// given: that is how the final attempt is routed to Config.FallbackBaseURL,
+// and how Config.ObserveCall sees the attempts.
+func (c *Client) retryableRequest(ctx context.Context, fn func(ctx context.Context) error) error {
+	return c.observeCall(ctx, func(ctx context.Context) error {
+		err := c.retryAttempts(ctx, fn)
+		if err == nil || c.fallbackURL == nil || ctx.Err() != nil || !isRetryableError(err) {
+			return err
+		}
+		// The primary host is still failing after every retry: send the same
+		// request once more, routed to the fallback host by odpEditor.
+		c.debug(ctx, "odp: trying fallback host", slog.String("host", c.fallbackURL.Host), slog.Any("error", err))
+		if fbErr := fn(withFallbackRoute(ctx)); fbErr != nil {
+			return fmt.Errorf("fallback %s: %w (primary: %v)", c.fallbackURL.Host, fbErr, err)
+		}
+		return nil
+	})
+}
+
+// retryLoop runs fn up to MaxRetries+1 times, backing off between retryable
+// failures. It never falls back to another host; the OA and TSDR APIs and
+// absolute download URIs use it directly because a mirror of the ODP API
+// does not serve them. As with retryableRequest, fn must send its requests
+// with the context it is given.
+func (c *Client) retryLoop(ctx context.Context, fn func(ctx context.Context) error) error {
+	return c.observeCall(ctx, func(ctx context.Context) error {
+		return c.retryAttempts(ctx, fn)
+	})
+}
+
+// retryAttempts is the retry loop shared by retryableRequest and retryLoop.
+func (c *Client) retryAttempts(ctx context.Context, fn func(ctx context.Context) error) error {
 	var lastErr error
 	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
-		err := fn()
+		err := fn(ctx)
 		if err == nil {
 			return nil
 		}
@@ -233,6 +506,8 @@ func (c *Client) retryableRequest(ctx context.Context, fn func() error) error {
 		// surface the error so the caller can decide.
 		var apiErr *APIError
 		if errors.As(err, &apiErr) && apiErr.RetryAfter > c.maxRetryAfter() {
+			c.debug(ctx, "odp: not retrying, Retry-After exceeds MaxRetryAfter",
+				slog.Int("attempt", attempt+1), slog.Duration("retry_after", apiErr.RetryAfter))
 			return err
 		}
 
@@ -243,18 +518,20 @@ func (c *Client) retryableRequest(ctx context.Context, fn func() error) error {
 			if apiErr != nil && apiErr.RetryAfter > 0 {
 				wait = apiErr.RetryAfter
 			} else {
-				// RetryDelay is a time.Duration (nanoseconds under the hood);
-				// the float64 round-trip stays in nanos, so the final
-				// time.Duration cast carries the right unit.
-				base := float64(c.config.RetryDelay)
-				delay := base * math.Pow(2, float64(attempt))
-				jitter := delay * 0.25 * rand.Float64()
-				wait = time.Duration(delay + jitter)
+				wait = c.backoffDelay(attempt)
 			}
-
+			c.debug(ctx, "odp: retrying request",
+				slog.Int("attempt", attempt+1), slog.Int("max_retries", c.config.MaxRetries),
+				slog.Duration("wait", wait), slog.Any("error", err))
+
+			// A stoppable timer rather than time.After, so a cancelled
+			// context releases the timer instead of leaving it pending for
+			// the rest of the backoff.
+			timer := time.NewTimer(wait)
 			select {
-			case <-time.After(wait):
+			case <-timer.C:
 			case <-ctx.Done():
+				timer.Stop()
 				return fmt.Errorf("request cancelled during retry: %w", ctx.Err())
 			}
 		}
@@ -285,10 +562,53 @@ func (pr *progressReader) Read(p []byte) (int, error) {
 	return n, err
 }
 
-// SearchPatents searches for patent applications. It is the simple form of
-// SearchPatentsWithOptions (query + pagination, no sort or field projection).
-func (c *Client) SearchPatents(ctx context.Context, query string, offset, limit int) (*generated.PatentDataResponse, error) {
-	return c.SearchPatentsWithOptions(ctx, query, offset, limit, nil)
+// SearchPatents searches for patent applications. opts add sort keys, a
+// response field projection or term filters, as SearchPatentsWithOptions
+// does with a PatentSearchOptions:
+//
+//	resp, err := client.SearchPatents(ctx, "artificial intelligence", 0, 25,
+//		odp.WithSort("applicationMetaData.filingDate", "Desc"),
+//		odp.WithFields("applicationNumberText", "applicationMetaData.inventionTitle"))
+func (c *Client) SearchPatents(ctx context.Context, query string, offset, limit int, opts ...SearchOption) (*generated.PatentDataResponse, error) {
+	var o PatentSearchOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return c.SearchPatentsWithOptions(ctx, query, offset, limit, &o)
+}
+
+// SearchPatentsGET runs the same search as SearchPatents through the GET
+// variant of the endpoint, with the query and pagination as URL parameters.
+// Requests without a body are easier for HTTP caches and CDNs to serve.
+func (c *Client) SearchPatentsGET(ctx context.Context, query string, offset, limit int) (*generated.PatentDataResponse, error) {
+	if err := validatePagination(offset, limit); err != nil {
+		return nil, err
+	}
+	params := &generated.GetApiV1PatentApplicationsSearchParams{
+		Offset: IntPtr(offset),
+		Limit:  IntPtr(limit),
+	}
+	if query != "" {
+		params.Q = StringPtr(query)
+	}
+
+	var resp *generated.GetApiV1PatentApplicationsSearchResponse
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = c.generated.GetApiV1PatentApplicationsSearchWithResponse(ctx, params)
+		if err != nil {
+			return err
+		}
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return resp.JSON200, nil
 }
 
 // PatentSearchSort is one sort key for a patent search: a document field and an
@@ -324,6 +644,31 @@ type PatentSearchOptions struct {
 	RangeFilters []PatentSearchRange
 }
 
+// SearchOption adds one refinement to the PatentSearchOptions a
+// SearchPatents call is sent with.
+type SearchOption func(*PatentSearchOptions)
+
+// WithSort adds a sort key on field; order is "Asc" or "Desc", or empty for
+// the API default. Keys apply in the order given.
+func WithSort(field, order string) SearchOption {
+	return func(o *PatentSearchOptions) {
+		o.Sort = append(o.Sort, PatentSearchSort{Field: field, Order: order})
+	}
+}
+
+// WithFields limits each returned record to fields, such as
+// "applicationNumberText", to cut the response size.
+func WithFields(fields ...string) SearchOption {
+	return func(o *PatentSearchOptions) { o.Fields = append(o.Fields, fields...) }
+}
+
+// WithFilter keeps only records whose field has one of values.
+func WithFilter(field string, values ...string) SearchOption {
+	return func(o *PatentSearchOptions) {
+		o.Filters = append(o.Filters, PatentSearchFilter{Field: field, Values: values})
+	}
+}
+
 // SearchPatentsWithOptions searches for patent applications with optional sort
 // and field-projection refinements. A nil opts behaves exactly like SearchPatents.
 func (c *Client) SearchPatentsWithOptions(ctx context.Context, query string, offset, limit int, opts *PatentSearchOptions) (*generated.PatentDataResponse, error) {
@@ -352,15 +697,38 @@ func (c *Client) SearchPatentsWithOptions(ctx context.Context, query string, off
 			req.RangeFilters = &ranges
 		}
 	}
+	return c.SearchPatentsAdvanced(ctx, req)
+}
+
+// CountPatents returns how many patent applications match query, for
+// dashboards that need the total but not the records. It asks for a single
+// record projected to its application number, so almost nothing but the
+// count is transferred.
+func (c *Client) CountPatents(ctx context.Context, query string) (int, error) {
+	resp, err := c.SearchPatentsWithOptions(ctx, query, 0, 1, &PatentSearchOptions{
+		Fields: []string{"applicationNumberText"},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if resp == nil {
+		return 0, nil
+	}
+	return derefInt(resp.Count), nil
+}
 
+// SearchPatentsAdvanced sends a caller-built search request to the patent
+// search endpoint unchanged, for queries the convenience wrappers cannot
+// express (facets, multiple sort keys, arbitrary filter combinations).
+func (c *Client) SearchPatentsAdvanced(ctx context.Context, req generated.PatentSearchRequest) (*generated.PatentDataResponse, error) {
 	var resp *generated.PostApiV1PatentApplicationsSearchResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.PostApiV1PatentApplicationsSearchWithResponse(ctx, req)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -372,6 +740,195 @@ func (c *Client) SearchPatentsWithOptions(ctx context.Context, query string, off
 	return resp.JSON200, nil
 }
 
+// SearchPatentsStreamDecode runs the same search as SearchPatentsAdvanced
+// but decodes the response body incrementally, calling fn once per record in
+// patentFileWrapperDataBag as it is read. Only one record is held in memory
+// at a time, which keeps large pages (limit in the hundreds, each record with
+// full event and continuity bags) from being materialized as one slice.
+//
+// A non-nil error from fn stops decoding and is returned as-is. As with
+// streamDownload, only connection setup is retried; once records have been
+// handed to fn, a decode or transport error is returned without retry so fn
+// never sees a record twice.
+func (c *Client) SearchPatentsStreamDecode(ctx context.Context, req generated.PatentSearchRequest, fn func(*PatentFileWrapper) error) error {
+	if fn == nil {
+		return fmt.Errorf("callback is required")
+	}
+	var resp *http.Response
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
+		if resp != nil {
+			drainClose(resp.Body)
+			resp = nil
+		}
+		r, err := c.generated.PostApiV1PatentApplicationsSearch(ctx, req)
+		if err != nil {
+			return err
+		}
+		if r.StatusCode < 200 || r.StatusCode >= 300 {
+			body, _ := io.ReadAll(io.LimitReader(r.Body, 4096))
+			drainClose(r.Body)
+			return checkResponseStatus(r.StatusCode, body, r)
+		}
+		resp = r
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// Close without draining: when fn stops early, the rest of the stream
+	// may be large, and reading it only to reuse the connection defeats
+	// streaming.
+	defer resp.Body.Close()
+
+	dec := json.NewDecoder(resp.Body)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("decoding search response: %w", err)
+		}
+		if key, _ := tok.(string); key != "patentFileWrapperDataBag" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return fmt.Errorf("decoding search response field %q: %w", key, err)
+			}
+			if key == "requestIdentifier" {
+				var id string
+				if json.Unmarshal(skip, &id) == nil {
+					addRequestID(ctx, id)
+				}
+			}
+			continue
+		}
+		// The API sends null rather than [] for an empty bag.
+		if tok, err = dec.Token(); err != nil {
+			return fmt.Errorf("decoding search response: %w", err)
+		}
+		if tok == nil {
+			continue
+		}
+		if d, ok := tok.(json.Delim); !ok || d != '[' {
+			return fmt.Errorf("decoding search response: patentFileWrapperDataBag is %v, want array", tok)
+		}
+		for i := 0; dec.More(); i++ {
+			var rec PatentFileWrapper
+			if err := dec.Decode(&rec); err != nil {
+				return fmt.Errorf("decoding search record %d: %w", i, err)
+			}
+			if err := fn(&rec); err != nil {
+				return err
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+	return expectDelim(dec, '}')
+}
+
+// expectDelim reads the next token from dec and fails unless it is want.
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("decoding search response: %w", err)
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("decoding search response: got %v, want %q", tok, want)
+	}
+	return nil
+}
+
+// filingDateField is the search field holding an application's filing date.
+const filingDateField = "applicationMetaData.filingDate"
+
+// apiDateLayout is the yyyy-MM-dd format the ODP API uses for date values,
+// including the bounds of date range filters.
+const apiDateLayout = "2006-01-02"
+
+// SearchPatentsByFilingDateRange searches for applications filed between from
+// and to, inclusive. Only the calendar date of each bound is used. A zero
+// time.Time leaves that side of the range open.
+func (c *Client) SearchPatentsByFilingDateRange(ctx context.Context, from, to time.Time, offset, limit int) (*generated.PatentDataResponse, error) {
+	if from.IsZero() && to.IsZero() {
+		return nil, fmt.Errorf("filing date range requires at least one bound")
+	}
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return nil, fmt.Errorf("filing date range is inverted: from %s is after to %s",
+			from.Format(apiDateLayout), to.Format(apiDateLayout))
+	}
+	r := PatentSearchRange{Field: filingDateField}
+	if !from.IsZero() {
+		r.From = from.Format(apiDateLayout)
+	}
+	if !to.IsZero() {
+		r.To = to.Format(apiDateLayout)
+	}
+	if err := validatePagination(offset, limit); err != nil {
+		return nil, err
+	}
+	// No query is sent, so the range filter alone decides what matches.
+	ranges := buildSearchRanges([]PatentSearchRange{r})
+	return c.SearchPatentsAdvanced(ctx, generated.PatentSearchRequest{
+		RangeFilters: &ranges,
+		Pagination: &generated.Pagination{
+			Offset: Int32Ptr(int32(offset)),
+			Limit:  Int32Ptr(int32(limit)),
+		},
+	})
+}
+
+// SearchCursor records a position in a paginated patent search so that a long
+// crawl can be persisted (it marshals to JSON) and resumed later with
+// SearchPatentsFromCursor. Options carries any sort/filter refinements;
+// crawls that need a stable order across sessions should set a sort key.
+type SearchCursor struct {
+	Query   string               `json:"query"`
+	Offset  int                  `json:"offset"`
+	Options *PatentSearchOptions `json:"options,omitempty"`
+	// Done is set once a page comes back short or the offset reaches the
+	// reported total, i.e. there is nothing left to fetch.
+	Done bool `json:"done,omitempty"`
+}
+
+// NewSearchCursor returns a cursor positioned at the start of query.
+func NewSearchCursor(query string, opts *PatentSearchOptions) *SearchCursor {
+	return &SearchCursor{Query: query, Options: opts}
+}
+
+// SearchPatentsFromCursor fetches the next page of up to pageSize results at
+// the cursor's offset and advances the cursor past the records returned, so
+// the next call (in this process or after reloading the cursor) continues
+// where this one stopped. Calling it on a Done cursor returns an empty
+// response without a request.
+func (c *Client) SearchPatentsFromCursor(ctx context.Context, cursor *SearchCursor, pageSize int) (*generated.PatentDataResponse, error) {
+	if cursor == nil {
+		return nil, fmt.Errorf("search cursor cannot be nil")
+	}
+	if pageSize <= 0 {
+		return nil, fmt.Errorf("page size must be > 0, got %d", pageSize)
+	}
+	if cursor.Done {
+		return &generated.PatentDataResponse{}, nil
+	}
+
+	resp, err := c.SearchPatentsWithOptions(ctx, cursor.Query, cursor.Offset, pageSize, cursor.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	n := 0
+	if resp != nil && resp.PatentFileWrapperDataBag != nil {
+		n = len(*resp.PatentFileWrapperDataBag)
+	}
+	cursor.Offset += n
+	if n < pageSize || (resp != nil && resp.Count != nil && cursor.Offset >= *resp.Count) {
+		cursor.Done = true
+	}
+	return resp, nil
+}
+
 // buildSearchSort maps the public sort keys onto the generated Sort entries,
 // skipping keys with no field. An empty order is left unset so the API applies
 // its default; otherwise it is normalized to the API's "Asc"/"Desc" spelling.
@@ -429,20 +986,29 @@ func buildSearchRanges(ranges []PatentSearchRange) []generated.Range {
 	return out
 }
 
+// grantNumberQuery is the search query that finds the application a grant
+// number was issued on.
+func grantNumberQuery(grantNumber string) string {
+	return fmt.Sprintf("applicationMetaData.patentNumber:%s", grantNumber)
+}
+
 // resolveGrantToApplicationNumber searches for a grant number and returns its application number
 func (c *Client) resolveGrantToApplicationNumber(ctx context.Context, grantNumber string) (string, error) {
-	query := fmt.Sprintf("applicationMetaData.patentNumber:%s", grantNumber)
+	query := grantNumberQuery(grantNumber)
 
 	result, err := c.SearchPatents(ctx, query, 0, 1)
 	if err != nil {
+		traceStep(ctx, PatentNumberTypeGrant, query, "")
 		return "", fmt.Errorf("failed to search for grant number %s: %w", grantNumber, err)
 	}
 
 	if result.PatentFileWrapperDataBag == nil || len(*result.PatentFileWrapperDataBag) == 0 {
+		traceStep(ctx, PatentNumberTypeGrant, query, "")
 		return "", fmt.Errorf("no application found for grant number %s", grantNumber)
 	}
 
 	patent := (*result.PatentFileWrapperDataBag)[0]
+	traceStep(ctx, PatentNumberTypeGrant, query, derefStr(patent.ApplicationNumberText))
 	if patent.ApplicationNumberText == nil {
 		return "", fmt.Errorf("application number not found in response for grant number %s", grantNumber)
 	}
@@ -467,14 +1033,17 @@ func (c *Client) resolvePublicationToApplicationNumber(ctx context.Context, publ
 
 	result, err := c.SearchPatents(ctx, query, 0, 1)
 	if err != nil {
+		traceStep(ctx, PatentNumberTypePublication, query, "")
 		return "", fmt.Errorf("failed to search for publication number %s: %w", publicationNumber, err)
 	}
 
 	if result.PatentFileWrapperDataBag == nil || len(*result.PatentFileWrapperDataBag) == 0 {
+		traceStep(ctx, PatentNumberTypePublication, query, "")
 		return "", fmt.Errorf("no application found for publication number %s", publicationNumber)
 	}
 
 	patent := (*result.PatentFileWrapperDataBag)[0]
+	traceStep(ctx, PatentNumberTypePublication, query, derefStr(patent.ApplicationNumberText))
 	if patent.ApplicationNumberText == nil {
 		return "", fmt.Errorf("application number not found in response for publication number %s", publicationNumber)
 	}
@@ -494,10 +1063,16 @@ func (c *Client) ResolvePatentNumber(ctx context.Context, patentNumber string) (
 	if err != nil {
 		return "", fmt.Errorf("invalid patent number: %w", err)
 	}
+	traceStart(ctx, patentNumber, pn)
+	var appNumber string
 	if pn.Type == PatentNumberTypeApplication && pn.Ambiguous {
-		return c.resolveAmbiguousNumber(ctx, pn.Normalized)
+		appNumber, err = c.cachedResolve(ctx, resolveCacheKey(pn, true), func() (string, error) {
+			return c.resolveAmbiguousNumber(ctx, pn.Normalized)
+		})
+	} else {
+		appNumber, err = c.resolveNormalized(ctx, pn)
 	}
-	return c.resolveNormalized(ctx, pn)
+	return traceResult(ctx, appNumber, err)
 }
 
 // resolveApplicationNumberLenient resolves a patent number without ambiguity probing:
@@ -511,20 +1086,27 @@ func (c *Client) resolveApplicationNumberLenient(ctx context.Context, patentNumb
 	if err != nil {
 		return "", fmt.Errorf("invalid patent number: %w", err)
 	}
-	return c.resolveNormalized(ctx, pn)
+	traceStart(ctx, patentNumber, pn)
+	appNumber, err := c.resolveNormalized(ctx, pn)
+	return traceResult(ctx, appNumber, err)
 }
 
 // resolveNormalized maps a parsed patent number to its application number without any
-// ambiguity probing.
+// ambiguity probing. Grant and publication lookups go through the resolution cache.
 func (c *Client) resolveNormalized(ctx context.Context, pn *PatentNumber) (string, error) {
 	switch pn.Type {
 	case PatentNumberTypeGrant:
-		return c.resolveGrantToApplicationNumber(ctx, pn.Normalized)
+		return c.cachedResolve(ctx, resolveCacheKey(pn, false), func() (string, error) {
+			return c.resolveGrantToApplicationNumber(ctx, pn.Normalized)
+		})
 	case PatentNumberTypePublication:
-		return c.resolvePublicationToApplicationNumber(ctx, pn.Normalized, pn.KindCode)
+		return c.cachedResolve(ctx, resolveCacheKey(pn, false), func() (string, error) {
+			return c.resolvePublicationToApplicationNumber(ctx, pn.Normalized, pn.KindCode)
+		})
 	case PatentNumberTypeApplication, PatentNumberTypePCT:
 		// PCT numbers (15-char or 12-char legacy) are accepted directly as the
 		// application path parameter; no round-trip needed.
+		traceStep(ctx, pn.Type, "", pn.ToApplicationNumber())
 		return pn.ToApplicationNumber(), nil
 	default:
 		return "", fmt.Errorf("unknown patent number type")
@@ -575,10 +1157,16 @@ func (c *Client) resolveAmbiguousNumber(ctx context.Context, digits string) (str
 	if err != nil {
 		return "", err
 	}
+	traceStep(ctx, PatentNumberTypeGrant, grantNumberQuery(digits), grantApp)
 	appTitle, appFound, err := c.findApplicationCandidate(ctx, digits)
 	if err != nil {
 		return "", err
 	}
+	if appFound {
+		traceStep(ctx, PatentNumberTypeApplication, "", digits)
+	} else {
+		traceStep(ctx, PatentNumberTypeApplication, "", "")
+	}
 
 	switch {
 	case grantFound && appFound && grantApp != digits:
@@ -603,7 +1191,7 @@ func (c *Client) resolveAmbiguousNumber(ctx context.Context, digits string) (str
 // application it maps to plus its title. found is false (with nil error) when no grant
 // matches.
 func (c *Client) findGrantCandidate(ctx context.Context, grantNumber string) (appNumber, title string, found bool, err error) {
-	query := fmt.Sprintf("applicationMetaData.patentNumber:%s", grantNumber)
+	query := grantNumberQuery(grantNumber)
 	result, err := c.SearchPatents(ctx, query, 0, 1)
 	if err != nil {
 		if isNotFoundErr(err) {
@@ -626,7 +1214,7 @@ func (c *Client) findGrantCandidate(ctx context.Context, grantNumber string) (ap
 // It calls the generated endpoint directly to avoid recursing back into resolution.
 func (c *Client) findApplicationCandidate(ctx context.Context, appNumber string) (title string, found bool, err error) {
 	var resp *generated.GetApiV1PatentApplicationsApplicationNumberTextResponse
-	err = c.retryableRequest(ctx, func() error {
+	err = c.retryableRequest(ctx, func(ctx context.Context) error {
 		var reqErr error
 		resp, reqErr = c.generated.GetApiV1PatentApplicationsApplicationNumberTextWithResponse(ctx, appNumber)
 		if reqErr != nil {
@@ -635,7 +1223,7 @@ func (c *Client) findApplicationCandidate(ctx context.Context, appNumber string)
 		if resp.StatusCode() == http.StatusNotFound {
 			return nil // not found is a definitive answer, not a transient failure
 		}
-		return checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse))
+		return checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse)
 	})
 	if err != nil {
 		return "", false, err
@@ -660,8 +1248,7 @@ func titleOf(meta *generated.ApplicationMetaData) string {
 // isNotFoundErr reports whether err is an APIError with HTTP 404 (used to treat an
 // empty ODP search, which returns 404, as "no match" rather than a hard failure).
 func isNotFoundErr(err error) bool {
-	var apiErr *APIError
-	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+	return errors.Is(err, ErrNotFound)
 }
 
 // GetPatent retrieves patent data by application, grant, or publication number.
@@ -675,13 +1262,13 @@ func (c *Client) GetPatent(ctx context.Context, patentNumber string) (*generated
 	}
 
 	var resp *generated.GetApiV1PatentApplicationsApplicationNumberTextResponse
-	err = c.retryableRequest(ctx, func() error {
+	err = c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PatentApplicationsApplicationNumberTextWithResponse(ctx, applicationNumber)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -693,16 +1280,71 @@ func (c *Client) GetPatent(ctx context.Context, patentNumber string) (*generated
 	return resp.JSON200, nil
 }
 
+// GetPatents fetches several patents with GetPatent, running up to
+// concurrency lookups at a time (values below 1 mean one at a time).
+// results[i] and errs[i] belong to numbers[i]: a number that fails leaves
+// results[i] nil and does not stop the others. Once ctx is cancelled,
+// numbers not yet started fail with ctx.Err(). A ResolutionTrace on ctx is
+// left untouched: it describes one resolution and is not safe for the
+// concurrent lookups, which run without it.
+func (c *Client) GetPatents(ctx context.Context, numbers []string, concurrency int) ([]*generated.PatentDataResponse, []error) {
+	results := make([]*generated.PatentDataResponse, len(numbers))
+	errs := make([]error, len(numbers))
+	concurrency = max(1, min(concurrency, len(numbers)))
+	if resolutionTraceFrom(ctx) != nil {
+		ctx = context.WithValue(ctx, resolutionTraceKey{}, (*ResolutionTrace)(nil))
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Go(func() {
+			for i := range next {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				results[i], errs[i] = c.GetPatent(ctx, numbers[i])
+			}
+		})
+	}
+	for i := range numbers {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return results, errs
+}
+
+// GrantNumberForApplication reports whether an application has been granted
+// and, if so, its patent number, read from applicationMetaData.patentNumber.
+// An application that exists but has not issued returns ("", false, nil).
+func (c *Client) GrantNumberForApplication(ctx context.Context, applicationNumber string) (string, bool, error) {
+	resp, err := c.GetPatent(ctx, applicationNumber)
+	if err != nil {
+		return "", false, err
+	}
+	if resp == nil || resp.PatentFileWrapperDataBag == nil || len(*resp.PatentFileWrapperDataBag) == 0 {
+		return "", false, fmt.Errorf("no record returned for application %s", applicationNumber)
+	}
+	pfw := (*resp.PatentFileWrapperDataBag)[0]
+	if pfw.ApplicationMetaData == nil {
+		return "", false, nil
+	}
+	patentNumber := derefStr(pfw.ApplicationMetaData.PatentNumber)
+	return patentNumber, patentNumber != "", nil
+}
+
 // GetPatentAdjustment retrieves patent term adjustment data.
 func (c *Client) GetPatentAdjustment(ctx context.Context, applicationNumber string) (*AdjustmentResponse, error) {
 	var resp *generated.GetApiV1PatentApplicationsApplicationNumberTextAdjustmentResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PatentApplicationsApplicationNumberTextAdjustmentWithResponse(ctx, applicationNumber)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -712,30 +1354,74 @@ func (c *Client) GetPatentAdjustment(ctx context.Context, applicationNumber stri
 		return nil, err
 	}
 
+	// Decoded from the raw body rather than resp.JSON200: the generated
+	// types hold sequence numbers and day quantities as float32, which
+	// cannot represent every value the API sends.
+	var raw struct {
+		PatentFileWrapperDataBag []struct {
+			PatentTermAdjustmentData *struct {
+				AdjustmentTotalQuantity         int     `json:"adjustmentTotalQuantity"`
+				ADelayQuantity                  int     `json:"aDelayQuantity"`
+				BDelayQuantity                  int     `json:"bDelayQuantity"`
+				CDelayQuantity                  int     `json:"cDelayQuantity"`
+				OverlappingDayQuantity          float64 `json:"overlappingDayQuantity"`
+				ApplicantDayDelayQuantity       int     `json:"applicantDayDelayQuantity"`
+				IpOfficeAdjustmentDelayQuantity float64 `json:"ipOfficeAdjustmentDelayQuantity"`
+				History                         []struct {
+					EventDate                      string  `json:"eventDate"`
+					EventDescriptionText           string  `json:"eventDescriptionText"`
+					PtaPTECode                     string  `json:"ptaPTECode"`
+					EventSequenceNumber            float64 `json:"eventSequenceNumber"`
+					OriginatingEventSequenceNumber float64 `json:"originatingEventSequenceNumber"`
+					ApplicantDayDelayQuantity      int     `json:"applicantDayDelayQuantity"`
+					IpOfficeDayDelayQuantity       int     `json:"ipOfficeDayDelayQuantity"`
+				} `json:"patentTermAdjustmentHistoryDataBag"`
+			} `json:"patentTermAdjustmentData"`
+		} `json:"patentFileWrapperDataBag"`
+	}
+	if len(resp.Body) > 0 {
+		if err := json.Unmarshal(resp.Body, &raw); err != nil {
+			return nil, fmt.Errorf("decoding patent term adjustment for %s: %w", applicationNumber, err)
+		}
+	}
+
 	result := &AdjustmentResponse{ApplicationNumber: applicationNumber}
-	if resp.JSON200 != nil && resp.JSON200.PatentFileWrapperDataBag != nil && len(*resp.JSON200.PatentFileWrapperDataBag) > 0 {
-		bag := (*resp.JSON200.PatentFileWrapperDataBag)[0]
-		if bag.PatentTermAdjustmentData != nil {
-			pta := bag.PatentTermAdjustmentData
-			result.TotalAdjustmentDays = derefInt(pta.AdjustmentTotalQuantity)
-			result.ADelays = derefInt(pta.ADelayQuantity)
-			result.BDelays = derefInt(pta.BDelayQuantity)
-			result.CDelays = derefInt(pta.CDelayQuantity)
+	if len(raw.PatentFileWrapperDataBag) > 0 && raw.PatentFileWrapperDataBag[0].PatentTermAdjustmentData != nil {
+		pta := raw.PatentFileWrapperDataBag[0].PatentTermAdjustmentData
+		result.TotalAdjustmentDays = pta.AdjustmentTotalQuantity
+		result.ADelays = pta.ADelayQuantity
+		result.BDelays = pta.BDelayQuantity
+		result.CDelays = pta.CDelayQuantity
+		result.OverlapDays = int(math.Round(pta.OverlappingDayQuantity))
+		result.ApplicantDelays = pta.ApplicantDayDelayQuantity
+		result.OfficeAdjustments = int(math.Round(pta.IpOfficeAdjustmentDelayQuantity))
+		for _, h := range pta.History {
+			result.History = append(result.History, AdjustmentEvent{
+				Date:                      strings.TrimSpace(h.EventDate),
+				Description:               strings.TrimSpace(h.EventDescriptionText),
+				Code:                      strings.TrimSpace(h.PtaPTECode),
+				SequenceNumber:            h.EventSequenceNumber,
+				OriginatingSequenceNumber: h.OriginatingEventSequenceNumber,
+				ApplicantDays:             h.ApplicantDayDelayQuantity,
+				OfficeDays:                h.IpOfficeDayDelayQuantity,
+			})
 		}
 	}
 	return result, nil
 }
 
-// GetPatentContinuity retrieves patent continuity data.
+// GetPatentContinuity retrieves patent continuity data. PCT parent and child
+// numbers are given in the compact form (PCTUS2023017788) so they can be
+// passed straight back to GetPatentContinuity to follow the chain.
 func (c *Client) GetPatentContinuity(ctx context.Context, applicationNumber string) (*ContinuityResponse, error) {
 	var resp *generated.GetApiV1PatentApplicationsApplicationNumberTextContinuityResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PatentApplicationsApplicationNumberTextContinuityWithResponse(ctx, applicationNumber)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -755,22 +1441,26 @@ func (c *Client) GetPatentContinuity(ctx context.Context, applicationNumber stri
 		if bag.ParentContinuityBag != nil {
 			for _, p := range *bag.ParentContinuityBag {
 				result.Parents = append(result.Parents, ContinuityParent{
-					ApplicationNumber: derefStr(p.ParentApplicationNumberText),
+					ApplicationNumber: normalizePCTApplicationNumber(derefStr(p.ParentApplicationNumberText)),
 					PatentNumber:      derefStr(p.ParentPatentNumber),
 					FilingDate:        derefStr(p.ParentApplicationFilingDate),
 					Status:            derefStr(p.ParentApplicationStatusDescriptionText),
+					StatusCode:        p.ParentApplicationStatusCode,
 					RelationshipType:  mapRelationshipType(derefStr(p.ClaimParentageTypeCode), derefStr(p.ClaimParentageTypeCodeDescriptionText)),
+					RelationshipCode:  derefStr(p.ClaimParentageTypeCode),
 				})
 			}
 		}
 		if bag.ChildContinuityBag != nil {
 			for _, ch := range *bag.ChildContinuityBag {
 				result.Children = append(result.Children, ContinuityChild{
-					ApplicationNumber: derefStr(ch.ChildApplicationNumberText),
+					ApplicationNumber: normalizePCTApplicationNumber(derefStr(ch.ChildApplicationNumberText)),
 					PatentNumber:      derefStr(ch.ChildPatentNumber),
 					FilingDate:        derefStr(ch.ChildApplicationFilingDate),
 					Status:            derefStr(ch.ChildApplicationStatusDescriptionText),
+					StatusCode:        float32ToIntPtr(ch.ChildApplicationStatusCode),
 					RelationshipType:  mapRelationshipType(derefStr(ch.ClaimParentageTypeCode), derefStr(ch.ClaimParentageTypeCodeDescriptionText)),
+					RelationshipCode:  derefStr(ch.ClaimParentageTypeCode),
 				})
 			}
 		}
@@ -780,15 +1470,60 @@ func (c *Client) GetPatentContinuity(ctx context.Context, applicationNumber stri
 
 // GetPatentDocuments retrieves patent documents list
 func (c *Client) GetPatentDocuments(ctx context.Context, applicationNumber string) (*generated.DocumentBag, error) {
+	return c.getPatentDocuments(ctx, applicationNumber, &generated.GetApiV1PatentApplicationsApplicationNumberTextDocumentsParams{})
+}
+
+// DocumentFilter narrows GetPatentDocumentsFiltered. Zero fields do not
+// filter.
+type DocumentFilter struct {
+	Codes     []string  // document codes to keep, e.g. "CTNF", "NOA"
+	Direction string    // "INCOMING", "OUTGOING" or "INTERNAL"
+	From      time.Time // earliest official date, inclusive
+	To        time.Time // latest official date, inclusive
+}
+
+// GetPatentDocumentsFiltered retrieves the documents list like
+// GetPatentDocuments, keeping only the documents that match filter. Codes
+// and the date range are sent to the API; the API has no direction
+// parameter, so Direction is applied to the response.
+func (c *Client) GetPatentDocumentsFiltered(ctx context.Context, applicationNumber string, filter DocumentFilter) (*generated.DocumentBag, error) {
 	params := &generated.GetApiV1PatentApplicationsApplicationNumberTextDocumentsParams{}
+	if len(filter.Codes) > 0 {
+		params.DocumentCodes = StringPtr(strings.Join(filter.Codes, ","))
+	}
+	if !filter.From.IsZero() {
+		params.OfficialDateFrom = StringPtr(filter.From.Format(time.DateOnly))
+	}
+	if !filter.To.IsZero() {
+		params.OfficialDateTo = StringPtr(filter.To.Format(time.DateOnly))
+	}
+	bag, err := c.getPatentDocuments(ctx, applicationNumber, params)
+	if err != nil || bag == nil || bag.DocumentBag == nil || filter.Direction == "" {
+		return bag, err
+	}
+	kept := (*bag.DocumentBag)[:0]
+	for _, d := range *bag.DocumentBag {
+		direction := derefStr(d.DirectionCategory)
+		if direction == "" {
+			direction = derefStr(d.DocumentDirectionCategory)
+		}
+		if strings.EqualFold(direction, filter.Direction) {
+			kept = append(kept, d)
+		}
+	}
+	bag.DocumentBag = &kept
+	return bag, nil
+}
+
+func (c *Client) getPatentDocuments(ctx context.Context, applicationNumber string, params *generated.GetApiV1PatentApplicationsApplicationNumberTextDocumentsParams) (*generated.DocumentBag, error) {
 	var resp *generated.GetApiV1PatentApplicationsApplicationNumberTextDocumentsResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PatentApplicationsApplicationNumberTextDocumentsWithResponse(ctx, applicationNumber, params)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -800,17 +1535,34 @@ func (c *Client) GetPatentDocuments(ctx context.Context, applicationNumber strin
 	return resp.JSON200, nil
 }
 
+// GetPatentDocumentCodes returns how many documents of each document code
+// (e.g. "CTNF", "NOA", "EGRANT.PDF") an application's file wrapper holds.
+// Documents without a code are not counted.
+func (c *Client) GetPatentDocumentCodes(ctx context.Context, applicationNumber string) (map[string]int, error) {
+	bag, err := c.GetPatentDocuments(ctx, applicationNumber)
+	if err != nil {
+		return nil, err
+	}
+	codes := map[string]int{}
+	for _, d := range documentsFromBag(applicationNumber, bag) {
+		if d.Code != "" {
+			codes[d.Code]++
+		}
+	}
+	return codes, nil
+}
+
 // GetStatusCodes retrieves all patent status codes
 func (c *Client) GetStatusCodes(ctx context.Context) (*generated.StatusCodeSearchResponse, error) {
 	params := &generated.GetApiV1PatentStatusCodesParams{}
 	var resp *generated.GetApiV1PatentStatusCodesResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PatentStatusCodesWithResponse(ctx, params)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -822,6 +1574,130 @@ func (c *Client) GetStatusCodes(ctx context.Context) (*generated.StatusCodeSearc
 	return resp.JSON200, nil
 }
 
+// statusCodePageSize is the page size used to load the status-code table.
+const statusCodePageSize = 100
+
+// EnrichWithStatusDescriptions looks up the description of each wrapper's
+// applicationStatusCode and stores it in ApplicationMetaData's
+// ApplicationStatusDescriptionText, for wrappers that have a code but no
+// description, as search results projected to a few fields often do. The
+// status-code table is loaded on first use and cached for the life of the
+// client; it is not fetched at all when no wrapper needs it. Descriptions
+// the API already returned are left as they are, as are codes missing from
+// the table.
+func (c *Client) EnrichWithStatusDescriptions(ctx context.Context, wrappers []*PatentFileWrapper) error {
+	var pending []*generated.ApplicationMetaData
+	for _, w := range wrappers {
+		if w == nil || w.ApplicationMetaData == nil || w.ApplicationMetaData.ApplicationStatusCode == nil {
+			continue
+		}
+		if derefStr(w.ApplicationMetaData.ApplicationStatusDescriptionText) == "" {
+			pending = append(pending, w.ApplicationMetaData)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	table, err := c.statusCodeTable(ctx)
+	if err != nil {
+		return err
+	}
+	for _, m := range pending {
+		if desc, ok := table[*m.ApplicationStatusCode]; ok {
+			m.ApplicationStatusDescriptionText = StringPtr(desc)
+		}
+	}
+	return nil
+}
+
+// statusCodeTable returns the cached status code -> description table,
+// loading every page of GetStatusCodes the first time. Callers arriving
+// during the load share it rather than starting their own. The load runs
+// without the cancellation of the ctx that started it, so a caller that
+// gives up does not fail the others; each caller stops waiting when its
+// own ctx is done. A failed load is not cached.
+func (c *Client) statusCodeTable(ctx context.Context) (map[int]string, error) {
+	c.statusMu.Lock()
+	if table := c.statusDescriptions; table != nil {
+		c.statusMu.Unlock()
+		return table, nil
+	}
+	load := c.statusLoad
+	if load == nil {
+		load = &statusCodeLoad{done: make(chan struct{})}
+		c.statusLoad = load
+		go func() {
+			table, err := c.loadStatusCodeTable(context.WithoutCancel(ctx))
+			c.statusMu.Lock()
+			if err == nil {
+				c.statusDescriptions = table
+			}
+			c.statusLoad = nil
+			c.statusMu.Unlock()
+			load.table, load.err = table, err
+			close(load.done)
+		}()
+	}
+	c.statusMu.Unlock()
+
+	select {
+	case <-load.done:
+		return load.table, load.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// statusCodeLoad is an in-flight load of the status-code table; table and
+// err are set before done is closed.
+type statusCodeLoad struct {
+	done  chan struct{}
+	table map[int]string
+	err   error
+}
+
+// loadStatusCodeTable fetches every page of the status-code table.
+func (c *Client) loadStatusCodeTable(ctx context.Context) (map[int]string, error) {
+	table := map[int]string{}
+	for offset := 0; ; offset += statusCodePageSize {
+		params := &generated.GetApiV1PatentStatusCodesParams{
+			Offset: IntPtr(offset),
+			Limit:  IntPtr(statusCodePageSize),
+		}
+		var resp *generated.GetApiV1PatentStatusCodesResponse
+		err := c.retryableRequest(ctx, func(ctx context.Context) error {
+			var err error
+			resp, err = c.generated.GetApiV1PatentStatusCodesWithResponse(ctx, params)
+			if err != nil {
+				return err
+			}
+			return checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("loading status codes: %w", err)
+		}
+		if resp.JSON200 == nil || resp.JSON200.StatusCodeBag == nil {
+			break
+		}
+		added := 0
+		for _, sc := range *resp.JSON200.StatusCodeBag {
+			if sc.ApplicationStatusCode == nil {
+				continue
+			}
+			if _, dup := table[*sc.ApplicationStatusCode]; !dup {
+				added++
+			}
+			table[*sc.ApplicationStatusCode] = derefStr(sc.ApplicationStatusDescriptionText)
+		}
+		// Stop on a short page, at the advertised count, or when a server
+		// that ignores offset sends the same page again.
+		if len(*resp.JSON200.StatusCodeBag) < statusCodePageSize || len(table) >= derefInt(resp.JSON200.Count) || added == 0 {
+			break
+		}
+	}
+	return table, nil
+}
+
 // SearchBulkProducts searches for bulk data products
 func (c *Client) SearchBulkProducts(ctx context.Context, query string, offset, limit int) (*generated.BdssResponseBag, error) {
 	if err := validatePagination(offset, limit); err != nil {
@@ -834,13 +1710,13 @@ func (c *Client) SearchBulkProducts(ctx context.Context, query string, offset, l
 	}
 
 	var resp *generated.GetApiV1DatasetsProductsSearchResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1DatasetsProductsSearchWithResponse(ctx, params)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -856,13 +1732,13 @@ func (c *Client) SearchBulkProducts(ctx context.Context, query string, offset, l
 func (c *Client) GetBulkProduct(ctx context.Context, productID string) (*generated.BdssResponseProductBag, error) {
 	params := &generated.GetApiV1DatasetsProductsProductIdentifierParams{}
 	var resp *generated.GetApiV1DatasetsProductsProductIdentifierResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1DatasetsProductsProductIdentifierWithResponse(ctx, productID, params)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -874,6 +1750,62 @@ func (c *Client) GetBulkProduct(ctx context.Context, productID string) (*generat
 	return resp.JSON200, nil
 }
 
+// GetBulkProductSummary retrieves the key facts about a bulk data product
+// without the nested product and file bags. Sizes and counts are decoded from
+// the raw response: the generated model types them as float32, which cannot
+// hold a multi-gigabyte total size exactly.
+func (c *Client) GetBulkProductSummary(ctx context.Context, productID string) (*BulkProductSummary, error) {
+	params := &generated.GetApiV1DatasetsProductsProductIdentifierParams{}
+	var resp *generated.GetApiV1DatasetsProductsProductIdentifierResponse
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = c.generated.GetApiV1DatasetsProductsProductIdentifierWithResponse(ctx, productID, params)
+		if err != nil {
+			return err
+		}
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
+			return err
+		}
+		return checkEmptyBody(resp.StatusCode(), resp.Body)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var raw struct {
+		BulkDataProductBag []struct {
+			ProductIdentifier        string  `json:"productIdentifier"`
+			ProductTitleText         string  `json:"productTitleText"`
+			ProductFrequencyText     string  `json:"productFrequencyText"`
+			DaysOfWeekText           string  `json:"daysOfWeekText"`
+			ProductFromDate          string  `json:"productFromDate"`
+			ProductToDate            string  `json:"productToDate"`
+			ProductTotalFileSize     float64 `json:"productTotalFileSize"`
+			ProductFileTotalQuantity float64 `json:"productFileTotalQuantity"`
+			LastModifiedDateTime     string  `json:"lastModifiedDateTime"`
+		} `json:"bulkDataProductBag"`
+	}
+	if err := json.Unmarshal(resp.Body, &raw); err != nil {
+		return nil, fmt.Errorf("decoding bulk product %s: %w", productID, err)
+	}
+	if len(raw.BulkDataProductBag) == 0 {
+		return nil, fmt.Errorf("bulk product %s not found in response", productID)
+	}
+	p := raw.BulkDataProductBag[0]
+	return &BulkProductSummary{
+		ProductID:    strings.TrimSpace(p.ProductIdentifier),
+		Title:        strings.TrimSpace(p.ProductTitleText),
+		Frequency:    strings.TrimSpace(p.ProductFrequencyText),
+		DaysOfWeek:   strings.TrimSpace(p.DaysOfWeekText),
+		FromDate:     strings.TrimSpace(p.ProductFromDate),
+		ToDate:       strings.TrimSpace(p.ProductToDate),
+		TotalSize:    int64(p.ProductTotalFileSize),
+		FileCount:    int64(p.ProductFileTotalQuantity),
+		LastModified: strings.TrimSpace(p.LastModifiedDateTime),
+	}, nil
+}
+
 // validateFileDownloadURI validates that the URL is a proper FileDownloadURI from the USPTO API
 func (c *Client) validateFileDownloadURI(fileDownloadURI string) error {
 	if fileDownloadURI == "" {
@@ -906,14 +1838,28 @@ func (c *Client) DownloadBulkFileWithProgress(ctx context.Context, fileDownloadU
 // streamDownload performs an authenticated streaming GET of uri into w.
 //
 // Retry behavior: the connection-setup phase (request creation, transport
-// errors, non-2xx status) goes through retryableRequest with full backoff
+// errors, non-2xx status) goes through retryLoop with full backoff
 // and Retry-After honoring. Mid-stream errors (connection reset after the
 // 200 response started flowing) propagate without retry -- restarting from
 // zero would silently overwrite however many bytes the caller already
 // committed to its writer. URI validation is the caller's responsibility.
 func (c *Client) streamDownload(ctx context.Context, uri string, w io.Writer, progress func(bytesComplete int64, bytesTotal int64)) error {
+	return c.streamDownloadRange(ctx, uri, w, 0, -1, progress)
+}
+
+// streamDownloadRange is streamDownload of bytes start through end
+// (inclusive) only; a negative end means through the end of the file. Any
+// range other than the whole file sends a Range header and requires a 206
+// whose Content-Range starts at start; a server that ignores the range and
+// answers 200 with the whole file is an error rather than silently
+// corrupting w. For an open-ended range, a 416 whose Content-Range reports a
+// length equal to start means the file is already complete and returns nil.
+// The size check and progress total then cover only the requested bytes.
+func (c *Client) streamDownloadRange(ctx context.Context, uri string, w io.Writer, start, end int64, progress func(bytesComplete int64, bytesTotal int64)) error {
+	ranged := start > 0 || end >= 0
 	var resp *http.Response
-	err := c.retryableRequest(ctx, func() error {
+	complete := false
+	err := c.retryLoop(ctx, func(ctx context.Context) error {
 		// Discard any prior attempt's response before retrying.
 		if resp != nil {
 			drainClose(resp.Body)
@@ -927,15 +1873,37 @@ func (c *Client) streamDownload(ctx context.Context, uri string, w io.Writer, pr
 		if c.config.APIKey != "" {
 			req.Header.Set("X-API-Key", c.config.APIKey)
 		}
+		if end >= 0 {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end))
+		} else if start > 0 {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", start))
+		}
 		r, err := c.httpClient.Do(req)
 		if err != nil {
 			return err
 		}
+		if start > 0 && end < 0 && r.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+			if size, ok := contentRangeSize(r.Header.Get("Content-Range")); ok && size == start {
+				drainClose(r.Body)
+				complete = true
+				return nil
+			}
+		}
 		if r.StatusCode < 200 || r.StatusCode >= 300 {
 			// Read a bounded prefix of the error body for the APIError.
 			body, _ := io.ReadAll(io.LimitReader(r.Body, 4096))
 			drainClose(r.Body)
-			return checkResponseStatus(r.StatusCode, body, r.Header)
+			return checkResponseStatus(r.StatusCode, body, r)
+		}
+		if ranged {
+			if r.StatusCode != http.StatusPartialContent {
+				drainClose(r.Body)
+				return fmt.Errorf("server does not support range requests: got status %d", r.StatusCode)
+			}
+			if got, ok := contentRangeStart(r.Header.Get("Content-Range")); !ok || got != start {
+				drainClose(r.Body)
+				return fmt.Errorf("server returned range %q, want one starting at %d", r.Header.Get("Content-Range"), start)
+			}
 		}
 		resp = r
 		return nil
@@ -943,6 +1911,9 @@ func (c *Client) streamDownload(ctx context.Context, uri string, w io.Writer, pr
 	if err != nil {
 		return err
 	}
+	if complete {
+		return nil
+	}
 	defer drainClose(resp.Body)
 
 	expectedSize := resp.ContentLength
@@ -990,27 +1961,62 @@ func (c *Client) DownloadPatentDocument(ctx context.Context, downloadURL string,
 	return c.streamDownload(ctx, downloadURL, w, nil)
 }
 
+// DownloadPatentDocumentByID streams one file wrapper document of an
+// application to w, looking up its download URL in GetPatentDocuments.
+// documentIdentifier is the document's DocumentIdentifier; mimeType picks
+// the format ("PDF", "XML" or "MS_WORD", case-insensitive) and defaults to
+// PDF when empty. It errors when the document or that format is not listed.
+func (c *Client) DownloadPatentDocumentByID(ctx context.Context, applicationNumber, documentIdentifier, mimeType string, w io.Writer) error {
+	if documentIdentifier == "" {
+		return fmt.Errorf("documentIdentifier cannot be empty")
+	}
+	if mimeType == "" {
+		mimeType = "PDF"
+	}
+	bag, err := c.GetPatentDocuments(ctx, applicationNumber)
+	if err != nil {
+		return err
+	}
+	for _, doc := range documentsFromBag(applicationNumber, bag) {
+		if doc.Identifier != documentIdentifier {
+			continue
+		}
+		for _, d := range doc.Downloads {
+			if strings.EqualFold(d.MimeType, mimeType) && d.URL != "" {
+				return c.DownloadPatentDocument(ctx, d.URL, w)
+			}
+		}
+		return fmt.Errorf("document %s of application %s has no %s download", documentIdentifier, applicationNumber, mimeType)
+	}
+	return fmt.Errorf("document %s not found in application %s", documentIdentifier, applicationNumber)
+}
+
 // SearchPetitions searches for petition decisions
 func (c *Client) SearchPetitions(ctx context.Context, query string, offset, limit int) (*generated.PetitionDecisionResponseBag, error) {
 	if err := validatePagination(offset, limit); err != nil {
 		return nil, err
 	}
-	req := generated.PetitionDecisionSearchRequest{
+	return c.SearchPetitionsAdvanced(ctx, generated.PetitionDecisionSearchRequest{
 		Q: StringPtr(query),
 		Pagination: &generated.Pagination{
 			Offset: Int32Ptr(int32(offset)),
 			Limit:  Int32Ptr(int32(limit)),
 		},
-	}
+	})
+}
 
+// SearchPetitionsAdvanced sends a caller-built search request to the
+// petition decision search endpoint unchanged, for filters, date ranges,
+// sort orders and facets SearchPetitions cannot express.
+func (c *Client) SearchPetitionsAdvanced(ctx context.Context, req generated.PetitionDecisionSearchRequest) (*generated.PetitionDecisionResponseBag, error) {
 	var resp *generated.PostApiV1PetitionDecisionsSearchResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.PostApiV1PetitionDecisionsSearchWithResponse(ctx, req)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1025,13 +2031,13 @@ func (c *Client) SearchPetitions(ctx context.Context, query string, offset, limi
 // GetPatentAssignment retrieves patent assignment data.
 func (c *Client) GetPatentAssignment(ctx context.Context, applicationNumber string) (*AssignmentResponse, error) {
 	var resp *generated.GetApiV1PatentApplicationsApplicationNumberTextAssignmentResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PatentApplicationsApplicationNumberTextAssignmentWithResponse(ctx, applicationNumber)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1049,58 +2055,113 @@ func (c *Client) GetPatentAssignment(ctx context.Context, applicationNumber stri
 		bag := (*resp.JSON200.PatentFileWrapperDataBag)[0]
 		if bag.AssignmentBag != nil {
 			for _, a := range *bag.AssignmentBag {
-				entry := AssignmentEntry{
-					RecordedDate:        derefStr(a.AssignmentRecordedDate),
-					Conveyance:          derefStr(a.ConveyanceText),
-					ReelFrame:           derefStr(a.ReelAndFrameNumber),
-					MailedDate:          derefStr(a.AssignmentMailedDate),
-					ReceivedDate:        derefStr(a.AssignmentReceivedDate),
-					DocumentLocationURI: derefStr(a.AssignmentDocumentLocationURI),
-				}
-				if a.AssignorBag != nil {
-					for _, assignor := range *a.AssignorBag {
-						entry.Assignors = append(entry.Assignors, Assignor{
-							Name:          derefStr(assignor.AssignorName),
-							ExecutionDate: derefStr(assignor.ExecutionDate),
-						})
-					}
-				}
-				if a.AssigneeBag != nil {
-					for _, assignee := range *a.AssigneeBag {
-						p := Assignee{Name: derefStr(assignee.AssigneeNameText)}
-						if assignee.AssigneeAddress != nil {
-							addr := assignee.AssigneeAddress
-							p.City = derefStr(addr.CityName)
-							// 3.6 consolidated location into geographicRegionCode;
-							// fall back to the deprecated countryOrStateCode for
-							// older records.
-							p.GeographicRegion = derefStr(addr.GeographicRegionCode)
-							if p.GeographicRegion == "" {
-								p.GeographicRegion = derefStr(addr.CountryOrStateCode)
-							}
-							p.PostalCode = derefStr(addr.PostalCode)
-							p.CountryName = derefStr(addr.CountryName)
-						}
-						entry.Assignees = append(entry.Assignees, p)
-					}
-				}
-				result.Assignments = append(result.Assignments, entry)
+				result.Assignments = append(result.Assignments, assignmentEntryFromAPI(a))
 			}
 		}
 	}
 	return result, nil
 }
 
+// assignmentEntryFromAPI converts one assignmentBag record.
+func assignmentEntryFromAPI(a generated.Assignment) AssignmentEntry {
+	entry := AssignmentEntry{
+		RecordedDate:         derefStr(a.AssignmentRecordedDate),
+		Conveyance:           derefStr(a.ConveyanceText),
+		ReelFrame:            derefStr(a.ReelAndFrameNumber),
+		MailedDate:           derefStr(a.AssignmentMailedDate),
+		ReceivedDate:         derefStr(a.AssignmentReceivedDate),
+		DocumentLocationURI:  derefStr(a.AssignmentDocumentLocationURI),
+		ReelNumber:           derefInt(a.ReelNumber),
+		FrameNumber:          derefInt(a.FrameNumber),
+		PageCount:            derefInt(a.PageTotalQuantity),
+		ImageAvailable:       a.ImageAvailableStatusCode != nil && *a.ImageAvailableStatusCode,
+		AttorneyDocketNumber: derefStr(a.AttorneyDocketNumber),
+	}
+	if entry.ReelNumber == 0 && entry.FrameNumber == 0 {
+		entry.ReelNumber, entry.FrameNumber = parseReelFrame(entry.ReelFrame)
+	}
+	if a.CorrespondenceAddress != nil {
+		// correspondenceAddress is untyped in the spec.
+		if name, ok := (*a.CorrespondenceAddress)["correspondentNameText"].(string); ok {
+			entry.CorrespondentName = name
+		}
+	}
+	if a.AssignorBag != nil {
+		for _, assignor := range *a.AssignorBag {
+			entry.Assignors = append(entry.Assignors, Assignor{
+				Name:          derefStr(assignor.AssignorName),
+				ExecutionDate: derefStr(assignor.ExecutionDate),
+			})
+		}
+	}
+	if a.AssigneeBag != nil {
+		for _, assignee := range *a.AssigneeBag {
+			p := Assignee{Name: derefStr(assignee.AssigneeNameText)}
+			if assignee.AssigneeAddress != nil {
+				addr := assignee.AssigneeAddress
+				p.City = derefStr(addr.CityName)
+				// 3.6 consolidated location into geographicRegionCode;
+				// fall back to the deprecated countryOrStateCode for
+				// older records.
+				p.GeographicRegion = derefStr(addr.GeographicRegionCode)
+				if p.GeographicRegion == "" {
+					p.GeographicRegion = derefStr(addr.CountryOrStateCode)
+				}
+				p.PostalCode = derefStr(addr.PostalCode)
+				p.CountryName = derefStr(addr.CountryName)
+			}
+			entry.Assignees = append(entry.Assignees, p)
+		}
+	}
+	return entry
+}
+
+// SearchAssignments finds applications by their assignment records and
+// returns the full assignment chain of each match, plus the total number of
+// matching applications. ODP has no endpoint of its own for the USPTO
+// Assignment dataset, so this runs query against the patent search endpoint,
+// which indexes assignmentBag, and asks only for the assignment fields:
+//
+//	client.SearchAssignments(ctx, `assignmentBag.assigneeBag.assigneeNameText:"ACME CORP"`, 0, 25)
+//	client.SearchAssignments(ctx, `assignmentBag.reelNumber:38323`, 0, 25)
+//
+// A search with no matches returns an empty slice and no error.
+func (c *Client) SearchAssignments(ctx context.Context, query string, offset, limit int) ([]AssignmentResponse, int, error) {
+	resp, err := c.SearchPatentsWithOptions(ctx, query, offset, limit, &PatentSearchOptions{
+		Fields: []string{"applicationNumberText", "assignmentBag"},
+	})
+	if err != nil {
+		if isNotFoundErr(err) {
+			return []AssignmentResponse{}, 0, nil
+		}
+		return nil, 0, err
+	}
+	results := []AssignmentResponse{}
+	if resp == nil || resp.PatentFileWrapperDataBag == nil {
+		return results, 0, nil
+	}
+	for _, w := range *resp.PatentFileWrapperDataBag {
+		r := AssignmentResponse{ApplicationNumber: derefStr(w.ApplicationNumberText), Assignments: []AssignmentEntry{}}
+		if w.AssignmentBag != nil {
+			for _, a := range *w.AssignmentBag {
+				r.Assignments = append(r.Assignments, assignmentEntryFromAPI(a))
+			}
+		}
+		results = append(results, r)
+	}
+	return results, derefInt(resp.Count), nil
+}
+
 // GetPatentAssociatedDocuments retrieves patent grant and publication XML file metadata.
 func (c *Client) GetPatentAssociatedDocuments(ctx context.Context, applicationNumber string) (*AssociatedDocumentsResponse, error) {
 	var resp *generated.GetApiV1PatentApplicationsApplicationNumberTextAssociatedDocumentsResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PatentApplicationsApplicationNumberTextAssociatedDocumentsWithResponse(ctx, applicationNumber)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1122,13 +2183,13 @@ func (c *Client) GetPatentAssociatedDocuments(ctx context.Context, applicationNu
 // GetPatentAttorney retrieves patent attorney information.
 func (c *Client) GetPatentAttorney(ctx context.Context, applicationNumber string) (*generated.RecordAttorney, error) {
 	var resp *generated.GetApiV1PatentApplicationsApplicationNumberTextAttorneyResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PatentApplicationsApplicationNumberTextAttorneyWithResponse(ctx, applicationNumber)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1146,13 +2207,13 @@ func (c *Client) GetPatentAttorney(ctx context.Context, applicationNumber string
 // GetPatentForeignPriority retrieves foreign priority data.
 func (c *Client) GetPatentForeignPriority(ctx context.Context, applicationNumber string) (*ForeignPriorityResponse, error) {
 	var resp *generated.GetApiV1PatentApplicationsApplicationNumberTextForeignPriorityResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PatentApplicationsApplicationNumberTextForeignPriorityWithResponse(ctx, applicationNumber)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1183,13 +2244,13 @@ func (c *Client) GetPatentForeignPriority(ctx context.Context, applicationNumber
 // GetPatentMetaData retrieves patent metadata (status, filing date, examiner, classification, etc.).
 func (c *Client) GetPatentMetaData(ctx context.Context, applicationNumber string) (*MetaDataResponse, error) {
 	var resp *generated.GetApiV1PatentApplicationsApplicationNumberTextMetaDataResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PatentApplicationsApplicationNumberTextMetaDataWithResponse(ctx, applicationNumber)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1314,19 +2375,30 @@ func (c *Client) GetPatentMetaData(ctx context.Context, applicationNumber string
 			out.Inventors = append(out.Inventors, inv)
 		}
 	}
+	if c.config.CaptureUnknownFields {
+		var raw struct {
+			PatentFileWrapperDataBag []PatentRecord `json:"patentFileWrapperDataBag"`
+		}
+		if err := json.Unmarshal(resp.Body, &raw); err != nil {
+			return nil, fmt.Errorf("decoding unknown fields of %s: %w", applicationNumber, err)
+		}
+		if len(raw.PatentFileWrapperDataBag) > 0 {
+			out.Extra = raw.PatentFileWrapperDataBag[0].Extra
+		}
+	}
 	return out, nil
 }
 
 // GetPatentTransactions retrieves patent transaction history.
 func (c *Client) GetPatentTransactions(ctx context.Context, applicationNumber string) (*TransactionsResponse, error) {
 	var resp *generated.GetApiV1PatentApplicationsApplicationNumberTextTransactionsResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PatentApplicationsApplicationNumberTextTransactionsWithResponse(ctx, applicationNumber)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1358,16 +2430,16 @@ func (c *Client) GetPatentTransactions(ctx context.Context, applicationNumber st
 // SearchPatentsDownload downloads patent search results
 func (c *Client) SearchPatentsDownload(ctx context.Context, req generated.PatentDownloadRequest) ([]byte, error) {
 	var resp *generated.PostApiV1PatentApplicationsSearchDownloadResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.PostApiV1PatentApplicationsSearchDownloadWithResponse(ctx, req)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
-		return nil
+		return checkBodyLength(resp.HTTPResponse, resp.Body)
 	})
 
 	if err != nil {
@@ -1376,19 +2448,130 @@ func (c *Client) SearchPatentsDownload(ctx context.Context, req generated.Patent
 	return resp.Body, nil
 }
 
+// SearchPetitionsByRule searches for petition decisions citing a CFR rule or
+// statute, e.g. "37 CFR 1.137(a)" or "35 USC 27". Values starting with "35 USC"
+// are matched against statuteBag, everything else against ruleBag. The value
+// is matched as a quoted phrase, so parentheses and spaces need no escaping.
+func (c *Client) SearchPetitionsByRule(ctx context.Context, rule string, offset, limit int) (*generated.PetitionDecisionResponseBag, error) {
+	rule = strings.TrimSpace(rule)
+	if rule == "" {
+		return nil, fmt.Errorf("rule cannot be empty")
+	}
+	field := "ruleBag"
+	if strings.HasPrefix(strings.ToUpper(rule), "35 USC") {
+		field = "statuteBag"
+	}
+	return c.SearchPetitions(ctx, field+":"+quoteQueryValue(rule), offset, limit)
+}
+
+// quoteQueryValue wraps v in double quotes for use as a phrase in a search
+// query, escaping backslashes and embedded quotes.
+func quoteQueryValue(v string) string {
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `"`, `\"`)
+	return `"` + v + `"`
+}
+
+// petitionPageSize is the page size GetPetitionsForApplication fetches
+// with, and SearchPetitionsAll's default.
+const petitionPageSize = 100
+
+// GetPetitionsForApplication returns every petition decision filed in an
+// application, fetching as many pages of SearchPetitions as it takes. The
+// result's Count is the number of decisions returned.
+func (c *Client) GetPetitionsForApplication(ctx context.Context, applicationNumber string) (*generated.PetitionDecisionResponseBag, error) {
+	applicationNumber = strings.TrimSpace(applicationNumber)
+	if applicationNumber == "" {
+		return nil, fmt.Errorf("applicationNumber cannot be empty")
+	}
+	query := "applicationNumberText:" + quoteQueryValue(applicationNumber)
+
+	var decisions []generated.PetitionDecision
+	for d, err := range c.SearchPetitionsAll(ctx, query, petitionPageSize) {
+		if err != nil {
+			return nil, err
+		}
+		decisions = append(decisions, d)
+	}
+	count := len(decisions)
+	return &generated.PetitionDecisionResponseBag{
+		Count:                   &count,
+		PetitionDecisionDataBag: &decisions,
+	}, nil
+}
+
+// SearchPetitionsAll yields every petition decision matching query, fetching
+// pages of pageSize (100 when zero or less) with SearchPetitions as the loop
+// consumes them. It stops after the last page, which is the first one short
+// of pageSize or the one reaching the response's count. An error, including
+// a cancelled ctx, is yielded once and ends the sequence. Stop early by
+// breaking out of the loop:
+//
+//	for d, err := range client.SearchPetitionsAll(ctx, "revival", 0) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(*d.PetitionDecisionRecordIdentifier)
+//	}
+func (c *Client) SearchPetitionsAll(ctx context.Context, query string, pageSize int) iter.Seq2[generated.PetitionDecision, error] {
+	if pageSize <= 0 {
+		pageSize = petitionPageSize
+	}
+	return func(yield func(generated.PetitionDecision, error) bool) {
+		seen := 0
+		for offset := 0; ; offset += pageSize {
+			if err := ctx.Err(); err != nil {
+				yield(generated.PetitionDecision{}, err)
+				return
+			}
+			resp, err := c.SearchPetitions(ctx, query, offset, pageSize)
+			if err != nil {
+				yield(generated.PetitionDecision{}, err)
+				return
+			}
+			if resp == nil || resp.PetitionDecisionDataBag == nil {
+				return
+			}
+			page := *resp.PetitionDecisionDataBag
+			for _, d := range page {
+				if !yield(d, nil) {
+					return
+				}
+			}
+			seen += len(page)
+			if len(page) < pageSize || (resp.Count != nil && seen >= *resp.Count) {
+				return
+			}
+		}
+	}
+}
+
+// CountPetitions returns how many petition decisions match query, fetching
+// a single record rather than a page of them.
+func (c *Client) CountPetitions(ctx context.Context, query string) (int, error) {
+	resp, err := c.SearchPetitions(ctx, query, 0, 1)
+	if err != nil {
+		return 0, err
+	}
+	if resp == nil {
+		return 0, nil
+	}
+	return derefInt(resp.Count), nil
+}
+
 // GetPetitionDecision retrieves a specific petition decision
 func (c *Client) GetPetitionDecision(ctx context.Context, recordID string, includeDocuments bool) (*generated.PetitionDecisionIdentifierResponseBag, error) {
 	params := &generated.GetApiV1PetitionDecisionsPetitionDecisionRecordIdentifierParams{
 		IncludeDocuments: &includeDocuments,
 	}
 	var resp *generated.GetApiV1PetitionDecisionsPetitionDecisionRecordIdentifierResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PetitionDecisionsPetitionDecisionRecordIdentifierWithResponse(ctx, recordID, params)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1400,19 +2583,46 @@ func (c *Client) GetPetitionDecision(ctx context.Context, recordID string, inclu
 	return resp.JSON200, nil
 }
 
+// GetPetitionDecisionRaw retrieves a petition decision like GetPetitionDecision
+// but returns the response body untouched. Petition schemas change more often
+// than the generated types, so this is the escape hatch for fields the typed
+// response does not model yet.
+func (c *Client) GetPetitionDecisionRaw(ctx context.Context, recordID string, includeDocuments bool) (json.RawMessage, error) {
+	params := &generated.GetApiV1PetitionDecisionsPetitionDecisionRecordIdentifierParams{
+		IncludeDocuments: &includeDocuments,
+	}
+	var resp *generated.GetApiV1PetitionDecisionsPetitionDecisionRecordIdentifierResponse
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = c.generated.GetApiV1PetitionDecisionsPetitionDecisionRecordIdentifierWithResponse(ctx, recordID, params)
+		if err != nil {
+			return err
+		}
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
+			return err
+		}
+		return checkEmptyBody(resp.StatusCode(), resp.Body)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(resp.Body), nil
+}
+
 // SearchPetitionsDownload downloads petition search results
 func (c *Client) SearchPetitionsDownload(ctx context.Context, req generated.PetitionDecisionDownloadRequest) ([]byte, error) {
 	var resp *generated.PostApiV1PetitionDecisionsSearchDownloadResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.PostApiV1PetitionDecisionsSearchDownloadWithResponse(ctx, req)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
-		return nil
+		return checkBodyLength(resp.HTTPResponse, resp.Body)
 	})
 
 	if err != nil {
@@ -1454,13 +2664,13 @@ func (c *Client) SearchTrialProceedings(ctx context.Context, query string, offse
 	}
 
 	var resp *generated.PostApiV1PatentTrialsProceedingsSearchResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.PostApiV1PatentTrialsProceedingsSearchWithResponse(ctx, req)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1475,13 +2685,13 @@ func (c *Client) SearchTrialProceedings(ctx context.Context, query string, offse
 // GetTrialProceeding retrieves a specific PTAB trial proceeding by trial number
 func (c *Client) GetTrialProceeding(ctx context.Context, trialNumber string) (*generated.ProceedingDataResponse, error) {
 	var resp *generated.GetApiV1PatentTrialsProceedingsTrialNumberResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PatentTrialsProceedingsTrialNumberWithResponse(ctx, trialNumber)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1507,13 +2717,13 @@ func (c *Client) SearchTrialDecisions(ctx context.Context, query string, offset,
 	}
 
 	var resp *generated.PostApiV1PatentTrialsDecisionsSearchResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.PostApiV1PatentTrialsDecisionsSearchWithResponse(ctx, req)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1528,13 +2738,13 @@ func (c *Client) SearchTrialDecisions(ctx context.Context, query string, offset,
 // GetTrialDecision retrieves a specific PTAB trial decision by document identifier
 func (c *Client) GetTrialDecision(ctx context.Context, documentIdentifier string) (*generated.DecisionDataResponse, error) {
 	var resp *generated.GetApiV1PatentTrialsDecisionsDocumentIdentifierResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PatentTrialsDecisionsDocumentIdentifierWithResponse(ctx, documentIdentifier)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1560,13 +2770,13 @@ func (c *Client) SearchTrialDocuments(ctx context.Context, query string, offset,
 	}
 
 	var resp *generated.PostApiV1PatentTrialsDocumentsSearchResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.PostApiV1PatentTrialsDocumentsSearchWithResponse(ctx, req)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1581,13 +2791,13 @@ func (c *Client) SearchTrialDocuments(ctx context.Context, query string, offset,
 // GetTrialDocument retrieves a specific PTAB trial document by document identifier
 func (c *Client) GetTrialDocument(ctx context.Context, documentIdentifier string) (*generated.DocumentDataResponse, error) {
 	var resp *generated.GetApiV1PatentTrialsDocumentsDocumentIdentifierResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PatentTrialsDocumentsDocumentIdentifierWithResponse(ctx, documentIdentifier)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1613,13 +2823,13 @@ func (c *Client) SearchAppealDecisions(ctx context.Context, query string, offset
 	}
 
 	var resp *generated.PostApiV1PatentAppealsDecisionsSearchResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.PostApiV1PatentAppealsDecisionsSearchWithResponse(ctx, req)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1634,13 +2844,13 @@ func (c *Client) SearchAppealDecisions(ctx context.Context, query string, offset
 // GetAppealDecision retrieves a specific PTAB appeal decision by document identifier
 func (c *Client) GetAppealDecision(ctx context.Context, documentIdentifier string) (*generated.AppealDecisionDataResponse, error) {
 	var resp *generated.GetApiV1PatentAppealsDecisionsDocumentIdentifierResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PatentAppealsDecisionsDocumentIdentifierWithResponse(ctx, documentIdentifier)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1655,13 +2865,13 @@ func (c *Client) GetAppealDecision(ctx context.Context, documentIdentifier strin
 // GetAppealDecisionsByAppealNumber retrieves all decisions for a specific appeal number
 func (c *Client) GetAppealDecisionsByAppealNumber(ctx context.Context, appealNumber string) (*generated.AppealDecisionDataResponse, error) {
 	var resp *generated.GetApiV1PatentAppealsAppealNumberDecisionsResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PatentAppealsAppealNumberDecisionsWithResponse(ctx, appealNumber)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1687,13 +2897,13 @@ func (c *Client) SearchInterferenceDecisions(ctx context.Context, query string,
 	}
 
 	var resp *generated.PostApiV1PatentInterferencesDecisionsSearchResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.PostApiV1PatentInterferencesDecisionsSearchWithResponse(ctx, req)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1708,13 +2918,13 @@ func (c *Client) SearchInterferenceDecisions(ctx context.Context, query string,
 // GetInterferenceDecision retrieves a specific PTAB interference decision by document identifier
 func (c *Client) GetInterferenceDecision(ctx context.Context, documentIdentifier string) (*generated.InterferenceDecisionDataResponse, error) {
 	var resp *generated.GetApiV1PatentInterferencesDecisionsDocumentIdentifierResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PatentInterferencesDecisionsDocumentIdentifierWithResponse(ctx, documentIdentifier)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1729,13 +2939,13 @@ func (c *Client) GetInterferenceDecision(ctx context.Context, documentIdentifier
 // GetInterferenceDecisionsByNumber retrieves all decisions for a specific interference number
 func (c *Client) GetInterferenceDecisionsByNumber(ctx context.Context, interferenceNumber string) (*generated.InterferenceDecisionDataResponse, error) {
 	var resp *generated.GetApiV1PatentInterferencesInterferenceNumberDecisionsResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PatentInterferencesInterferenceNumberDecisionsWithResponse(ctx, interferenceNumber)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1750,13 +2960,13 @@ func (c *Client) GetInterferenceDecisionsByNumber(ctx context.Context, interfere
 // GetTrialDecisionsByTrialNumber retrieves all decisions for a specific trial number
 func (c *Client) GetTrialDecisionsByTrialNumber(ctx context.Context, trialNumber string) (*generated.DecisionDataResponse, error) {
 	var resp *generated.GetApiV1PatentTrialsTrialNumberDecisionsResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PatentTrialsTrialNumberDecisionsWithResponse(ctx, trialNumber)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1771,13 +2981,13 @@ func (c *Client) GetTrialDecisionsByTrialNumber(ctx context.Context, trialNumber
 // GetTrialDocumentsByTrialNumber retrieves all documents for a specific trial number
 func (c *Client) GetTrialDocumentsByTrialNumber(ctx context.Context, trialNumber string) (*generated.DocumentDataResponse, error) {
 	var resp *generated.GetApiV1PatentTrialsTrialNumberDocumentsResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.GetApiV1PatentTrialsTrialNumberDocumentsWithResponse(ctx, trialNumber)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		return nil
@@ -1792,16 +3002,16 @@ func (c *Client) GetTrialDocumentsByTrialNumber(ctx context.Context, trialNumber
 // SearchTrialProceedingsDownload downloads trial proceedings search results
 func (c *Client) SearchTrialProceedingsDownload(ctx context.Context, req generated.DownloadRequest) ([]byte, error) {
 	var resp *generated.PostApiV1PatentTrialsProceedingsSearchDownloadResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.PostApiV1PatentTrialsProceedingsSearchDownloadWithResponse(ctx, req)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
-		return nil
+		return checkBodyLength(resp.HTTPResponse, resp.Body)
 	})
 
 	if err != nil {
@@ -1813,16 +3023,16 @@ func (c *Client) SearchTrialProceedingsDownload(ctx context.Context, req generat
 // SearchTrialDecisionsDownload downloads trial decisions search results
 func (c *Client) SearchTrialDecisionsDownload(ctx context.Context, req generated.DownloadRequest) ([]byte, error) {
 	var resp *generated.PostApiV1PatentTrialsDecisionsSearchDownloadResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.PostApiV1PatentTrialsDecisionsSearchDownloadWithResponse(ctx, req)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
-		return nil
+		return checkBodyLength(resp.HTTPResponse, resp.Body)
 	})
 
 	if err != nil {
@@ -1834,16 +3044,16 @@ func (c *Client) SearchTrialDecisionsDownload(ctx context.Context, req generated
 // SearchTrialDocumentsDownload downloads trial documents search results
 func (c *Client) SearchTrialDocumentsDownload(ctx context.Context, req generated.DownloadRequest) ([]byte, error) {
 	var resp *generated.PostApiV1PatentTrialsDocumentsSearchDownloadResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.PostApiV1PatentTrialsDocumentsSearchDownloadWithResponse(ctx, req)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
-		return nil
+		return checkBodyLength(resp.HTTPResponse, resp.Body)
 	})
 
 	if err != nil {
@@ -1855,16 +3065,16 @@ func (c *Client) SearchTrialDocumentsDownload(ctx context.Context, req generated
 // SearchAppealDecisionsDownload downloads appeal decisions search results
 func (c *Client) SearchAppealDecisionsDownload(ctx context.Context, req generated.DownloadRequest) ([]byte, error) {
 	var resp *generated.PostApiV1PatentAppealsDecisionsSearchDownloadResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.PostApiV1PatentAppealsDecisionsSearchDownloadWithResponse(ctx, req)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
-		return nil
+		return checkBodyLength(resp.HTTPResponse, resp.Body)
 	})
 
 	if err != nil {
@@ -1876,16 +3086,16 @@ func (c *Client) SearchAppealDecisionsDownload(ctx context.Context, req generate
 // SearchInterferenceDecisionsDownload downloads interference decisions search results
 func (c *Client) SearchInterferenceDecisionsDownload(ctx context.Context, req generated.PatentDownloadRequest) ([]byte, error) {
 	var resp *generated.PostApiV1PatentInterferencesDecisionsSearchDownloadResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryableRequest(ctx, func(ctx context.Context) error {
 		var err error
 		resp, err = c.generated.PostApiV1PatentInterferencesDecisionsSearchDownloadWithResponse(ctx, req)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
-		return nil
+		return checkBodyLength(resp.HTTPResponse, resp.Body)
 	})
 
 	if err != nil {
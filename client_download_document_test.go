@@ -1,6 +1,14 @@
 package odp
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
 
 func TestValidateDocumentDownloadURL(t *testing.T) {
 	cfg := DefaultConfig()
@@ -30,3 +38,46 @@ func TestValidateDocumentDownloadURL(t *testing.T) {
 		})
 	}
 }
+
+func TestDownloadPatentDocumentByID(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/patent/applications/16123456/documents":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"documentBag":[{"documentIdentifier":"KX1","documentCode":"CTNF","downloadOptionBag":[`+
+				`{"mimeTypeIdentifier":"PDF","downloadUrl":"%[1]s/api/v1/download/applications/16123456/KX1.pdf"},`+
+				`{"mimeTypeIdentifier":"XML","downloadUrl":"%[1]s/api/v1/download/applications/16123456/KX1.xml"}]}]}`, server.URL)
+		case "/api/v1/download/applications/16123456/KX1.pdf":
+			_, _ = w.Write([]byte("%PDF-1.7"))
+		case "/api/v1/download/applications/16123456/KX1.xml":
+			_, _ = w.Write([]byte("<doc/>"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	c, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	if err := c.DownloadPatentDocumentByID(ctx, "16123456", "KX1", "", &buf); err != nil || buf.String() != "%PDF-1.7" {
+		t.Errorf("default PDF: got %q, %v", buf.String(), err)
+	}
+	buf.Reset()
+	if err := c.DownloadPatentDocumentByID(ctx, "16123456", "KX1", "xml", &buf); err != nil || buf.String() != "<doc/>" {
+		t.Errorf("XML: got %q, %v", buf.String(), err)
+	}
+	if err := c.DownloadPatentDocumentByID(ctx, "16123456", "KX1", "MS_WORD", &buf); err == nil || !strings.Contains(err.Error(), "no MS_WORD download") {
+		t.Errorf("missing format: err = %v", err)
+	}
+	if err := c.DownloadPatentDocumentByID(ctx, "16123456", "NOPE", "PDF", &buf); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("missing document: err = %v", err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"math"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -99,7 +100,7 @@ func TestParseRetryAfter_FutureHTTPDate(t *testing.T) {
 func TestAPIError_RetryAfter(t *testing.T) {
 	h := http.Header{}
 	h.Set("Retry-After", "7")
-	err := checkResponseStatus(429, []byte(`{"error":"rate limited"}`), h)
+	err := checkResponseStatus(429, []byte(`{"error":"rate limited"}`), &http.Response{Header: h})
 	var apiErr *APIError
 	if !errors.As(err, &apiErr) {
 		t.Fatalf("expected *APIError, got %T", err)
@@ -115,6 +116,44 @@ func TestAPIError_RetryAfter(t *testing.T) {
 	}
 }
 
+func TestAPIError_RequestIdentifierAndEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":404,"error":"Not Found","errorDetails":"No matching records found",
+			"requestIdentifier":"0a1b2c3d-4e5f-6789-abcd-ef0123456789"}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	_, err = client.GetPatentTransactions(context.Background(), "19999999")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("StatusCode = %d, want 404", apiErr.StatusCode)
+	}
+	if apiErr.RequestIdentifier != "0a1b2c3d-4e5f-6789-abcd-ef0123456789" {
+		t.Errorf("RequestIdentifier = %q", apiErr.RequestIdentifier)
+	}
+	if want := "GET /api/v1/patent/applications/19999999/transactions"; apiErr.Endpoint != want {
+		t.Errorf("Endpoint = %q, want %q", apiErr.Endpoint, want)
+	}
+
+	// Non-JSON bodies leave RequestIdentifier empty.
+	err = checkResponseStatus(http.StatusBadGateway, []byte("<html>Bad Gateway</html>"), nil)
+	if !errors.As(err, &apiErr) || apiErr.RequestIdentifier != "" || apiErr.Endpoint != "" {
+		t.Errorf("non-JSON body: got %+v", err)
+	}
+}
+
 // TestCheckEmptyBody verifies a success status with no body becomes a clear,
 // retryable error instead of being passed on to a parser that would fail with an
 // opaque "unexpected end of JSON input" / "EOF".
@@ -137,162 +176,1032 @@ func TestCheckEmptyBody(t *testing.T) {
 	}
 }
 
-// TestReadJSONResponse_Empty200 ensures an empty 200 surfaces as the clear
-// empty-body error rather than json.Unmarshal's "unexpected end of JSON input".
-func TestReadJSONResponse_Empty200(t *testing.T) {
-	resp := &http.Response{
-		StatusCode: 200,
-		Body:       io.NopCloser(strings.NewReader("")),
-		Header:     http.Header{},
+// TestReadJSONResponse_Empty200 ensures an empty 200 surfaces as the clear
+// empty-body error rather than json.Unmarshal's "unexpected end of JSON input".
+func TestReadJSONResponse_Empty200(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(strings.NewReader("")),
+		Header:     http.Header{},
+	}
+	var out map[string]any
+	err := readJSONResponse(resp, &out)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !apiErr.Empty {
+		t.Fatalf("empty 200: got %v (%T), want empty-body APIError", err, err)
+	}
+	if strings.Contains(err.Error(), "unexpected end of JSON") {
+		t.Errorf("should not surface the opaque json message: %q", err.Error())
+	}
+}
+
+func TestAPIError_IsRetryableStatusCodes(t *testing.T) {
+	retryable := map[int]bool{
+		http.StatusBadRequest:          false,
+		http.StatusUnauthorized:        false,
+		http.StatusForbidden:           false,
+		http.StatusNotFound:            false,
+		http.StatusTooManyRequests:     true,
+		http.StatusInternalServerError: true,
+		http.StatusNotImplemented:      false,
+		http.StatusBadGateway:          true,
+		http.StatusServiceUnavailable:  true,
+		http.StatusGatewayTimeout:      true,
+	}
+	for code, want := range retryable {
+		if got := (&APIError{StatusCode: code}).IsRetryable(); got != want {
+			t.Errorf("IsRetryable() for %d = %v, want %v", code, got, want)
+		}
+	}
+}
+
+// TestRetryableRequest_ClientErrorFailsFast checks that a 404 is returned
+// after a single request, as a typed *APIError carrying the status code.
+func TestRetryableRequest_ClientErrorFailsFast(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	cfg.MaxRetries = 3
+	cfg.RetryDelay = time.Millisecond
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	_, err = client.GetPatentContinuity(context.Background(), "19999999")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("err = %v, want *APIError with status 404", err)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("errors.Is(%v, ErrNotFound) = false", err)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("server hits = %d, want 1 (404 is not retried)", got)
+	}
+}
+
+func TestAPIError_IsSentinels(t *testing.T) {
+	sentinels := map[int]error{
+		http.StatusNotFound:        ErrNotFound,
+		http.StatusUnauthorized:    ErrUnauthorized,
+		http.StatusForbidden:       ErrUnauthorized,
+		http.StatusTooManyRequests: ErrRateLimited,
+	}
+	codes := []int{
+		http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound,
+		http.StatusGone, http.StatusTooManyRequests, http.StatusInternalServerError,
+	}
+	for _, code := range codes {
+		err := fmt.Errorf("wrapped: %w", &APIError{StatusCode: code})
+		for _, sentinel := range []error{ErrNotFound, ErrUnauthorized, ErrRateLimited} {
+			want := sentinels[code] == sentinel
+			if got := errors.Is(err, sentinel); got != want {
+				t.Errorf("errors.Is(status %d, %v) = %v, want %v", code, sentinel, got, want)
+			}
+		}
+	}
+}
+
+// TestRetryableRequest_ServerErrorBodyInMessage checks that a 500 is retried
+// and that the explanation in its JSON body reaches the final error.
+func TestRetryableRequest_ServerErrorBodyInMessage(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"code":500,"message":"Internal Server Error","detailedMessage":"Search index temporarily unavailable","requestIdentifier":"req-500"}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	cfg.MaxRetries = 2
+	cfg.RetryDelay = time.Millisecond
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	_, err = client.GetPatentContinuity(context.Background(), "17248024")
+	if got := hits.Load(); got != 3 {
+		t.Errorf("server hits = %d, want 3 (500 is retried)", got)
+	}
+	if err == nil || !strings.Contains(err.Error(), "Search index temporarily unavailable") {
+		t.Fatalf("err = %v, want the server's detailedMessage", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError || apiErr.RequestIdentifier != "req-500" {
+		t.Errorf("err = %#v, want *APIError 500 with the request identifier", apiErr)
+	}
+
+	for body, want := range map[string]string{
+		`{"message":"Bad gateway upstream"}`: ": Bad gateway upstream",
+		"upstream connect error":             ": upstream connect error",
+		"<html><body>502</body></html>":      "",
+		`{"unrelated":true}`:                 "",
+	} {
+		err := checkResponseStatus(http.StatusBadGateway, []byte(body), nil).(*APIError)
+		if got := strings.TrimPrefix(err.Message, "API returned status 502"); got != want {
+			t.Errorf("body %q: message suffix = %q, want %q", body, got, want)
+		}
+	}
+}
+
+// TestIsRetryableError_ContextErrors verifies that context cancellation and
+// deadline-exceeded are treated as caller intent, not transient network
+// failures. context.DeadlineExceeded satisfies net.Error.Timeout(), so without
+// the short-circuit it would be misclassified as a retryable timeout.
+func TestIsRetryableError_ContextErrors(t *testing.T) {
+	if isRetryableError(context.Canceled) {
+		t.Error("context.Canceled must not be retryable")
+	}
+	if isRetryableError(context.DeadlineExceeded) {
+		t.Error("context.DeadlineExceeded must not be retryable")
+	}
+	wrapped := fmt.Errorf("get failed: %w", context.DeadlineExceeded)
+	if isRetryableError(wrapped) {
+		t.Error("wrapped context.DeadlineExceeded must not be retryable")
+	}
+}
+
+// TestRetryableRequest_DeadlineExceededNotRetried ensures a per-request
+// deadline that fires does not trigger the retry loop: the server is hit once,
+// then the deadline-exceeded error propagates immediately.
+func TestRetryableRequest_DeadlineExceededNotRetried(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		// Block past the client's deadline; release on test teardown so the
+		// handler goroutine exits and server.Close() doesn't hang.
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	cfg.MaxRetries = 3
+	cfg.Timeout = 100 * time.Millisecond
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	_, err = client.SearchPatents(context.Background(), "x", 0, 1)
+	if err == nil {
+		t.Fatal("expected deadline error, got nil")
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("expected 1 server hit (deadline not retried), got %d", got)
+	}
+}
+
+// TestRetryableRequest_BackoffHonorsContextDeadline checks that a caller's
+// deadline cuts a long backoff short instead of blocking for the full delay.
+func TestRetryableRequest_BackoffHonorsContextDeadline(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	cfg.MaxRetries = 3
+	cfg.RetryDelay = 30 * time.Second
+	cfg.Timeout = 10 * time.Second
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = client.SearchPatents(ctx, "x", 0, 1)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("returned after %v, want promptly after the 100ms deadline", elapsed)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("expected 1 server hit before the deadline, got %d", got)
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	newClient := func(strategy BackoffStrategy, maxDelay time.Duration) *Client {
+		c, err := NewClient(&Config{BaseURL: "http://localhost", RetryDelay: 100 * time.Millisecond,
+			BackoffStrategy: strategy, MaxRetryDelay: maxDelay})
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		return c
+	}
+
+	linear := newClient(BackoffLinear, 0)
+	for attempt, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond} {
+		if got := linear.backoffDelay(attempt); got != want {
+			t.Errorf("linear attempt %d = %v, want %v", attempt, got, want)
+		}
+	}
+
+	exp := newClient(BackoffExponential, 0)
+	for attempt, base := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond} {
+		if got := exp.backoffDelay(attempt); got < base || got >= base*5/4 {
+			t.Errorf("exponential attempt %d = %v, want in [%v, %v)", attempt, got, base, base*5/4)
+		}
+	}
+
+	full := newClient(BackoffFullJitter, 0)
+	for i := 0; i < 50; i++ {
+		if got := full.backoffDelay(3); got < 0 || got >= 800*time.Millisecond {
+			t.Fatalf("full jitter attempt 3 = %v, want in [0, 800ms)", got)
+		}
+	}
+
+	capped := newClient(BackoffExponential, 250*time.Millisecond)
+	if got := capped.backoffDelay(5); got != 250*time.Millisecond {
+		t.Errorf("capped attempt 5 = %v, want MaxRetryDelay 250ms", got)
+	}
+}
+
+// TestRetryableRequest_FallbackBaseURL checks that a primary that keeps
+// failing is retried once against the fallback mirror, and that non-retryable
+// errors such as 404 are returned without touching the mirror.
+func TestRetryableRequest_FallbackBaseURL(t *testing.T) {
+	var primaryHits atomic.Int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryHits.Add(1)
+		if strings.HasSuffix(r.URL.Path, "/19999999") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+
+	var fallbackPaths []string
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fallbackPaths = append(fallbackPaths, r.URL.Path)
+		if r.Header.Get("X-API-Key") != "test" {
+			t.Errorf("fallback request missing API key")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":1,"patentFileWrapperDataBag":[{"applicationNumberText":"17248024",
+			"applicationMetaData":{"inventionTitle":"From the mirror"}}]}`))
+	}))
+	defer fallback.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = primary.URL
+	cfg.FallbackBaseURL = fallback.URL + "/mirror"
+	cfg.APIKey = "test"
+	cfg.MaxRetries = 2
+	cfg.RetryDelay = time.Millisecond
+	cfg.Timeout = 10 * time.Second
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	resp, err := client.GetPatent(context.Background(), "17248024")
+	if err != nil {
+		t.Fatalf("GetPatent: %v", err)
+	}
+	pfw := (*resp.PatentFileWrapperDataBag)[0]
+	if got := *pfw.ApplicationMetaData.InventionTitle; got != "From the mirror" {
+		t.Errorf("InventionTitle = %q, want the fallback's response", got)
+	}
+	if got := primaryHits.Load(); got != 3 {
+		t.Errorf("primary hits = %d, want 3 (1 + MaxRetries)", got)
+	}
+	if len(fallbackPaths) != 1 || fallbackPaths[0] != "/mirror/api/v1/patent/applications/17248024" {
+		t.Errorf("fallback paths = %v, want one request under /mirror", fallbackPaths)
+	}
+
+	primaryHits.Store(0)
+	fallbackPaths = nil
+	if _, err := client.GetPatent(context.Background(), "19999999"); !isNotFoundErr(err) {
+		t.Errorf("GetPatent(missing) error = %v, want 404", err)
+	}
+	if len(fallbackPaths) != 0 {
+		t.Errorf("404 was sent to the fallback: %v", fallbackPaths)
+	}
+
+	cfg.FallbackBaseURL = "not a url"
+	if _, err := NewClient(cfg); err == nil {
+		t.Error("NewClient accepted an invalid FallbackBaseURL")
+	}
+}
+
+func TestNewClient_ValidatesConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Config)
+		wantErr string
+	}{
+		{"empty BaseURL", func(c *Config) { c.BaseURL = "" }, "BaseURL"},
+		{"BaseURL without scheme", func(c *Config) { c.BaseURL = "api.uspto.gov" }, "BaseURL"},
+		{"unparseable BaseURL", func(c *Config) { c.BaseURL = "http://[::1" }, "BaseURL"},
+		{"negative Timeout", func(c *Config) { c.Timeout = -time.Second }, "Timeout"},
+		{"negative MaxRetries", func(c *Config) { c.MaxRetries = -1 }, "MaxRetries"},
+		{"negative RetryDelay", func(c *Config) { c.RetryDelay = -time.Millisecond }, "RetryDelay"},
+		{"negative MaxRetryDelay", func(c *Config) { c.MaxRetryDelay = -time.Second }, "MaxRetryDelay"},
+		{"negative MaxRetryAfter", func(c *Config) { c.MaxRetryAfter = -time.Second }, "MaxRetryAfter"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.mutate(cfg)
+			_, err := NewClient(cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewClient error = %v, want one naming %s", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Zero values stay valid: no timeout, no retries, no delay.
+	if _, err := NewClient(&Config{BaseURL: "http://localhost:8080"}); err != nil {
+		t.Errorf("NewClient with zero settings: %v", err)
+	}
+}
+
+func TestRetryableRequest_AboveCapSurfaces(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits++
+		w.Header().Set("Retry-After", "600")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":"rate limited"}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	cfg.MaxRetries = 3
+	cfg.MaxRetryAfter = 60 * time.Second // explicit; verifies the cap is honored
+	cfg.Timeout = 10 * time.Second
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	_, err = client.SearchPatents(context.Background(), "x", 0, 1)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.RetryAfter != 600*time.Second {
+		t.Errorf("RetryAfter = %v, want 600s", apiErr.RetryAfter)
+	}
+	if hits != 1 {
+		t.Errorf("expected 1 server hit (no retry above cap), got %d", hits)
+	}
+}
+
+// TestRetryableRequest_HonorsRetryAfter exercises the full retry path: a
+// server returns 429 with Retry-After: 1 once, then 200, and the client
+// succeeds on the second attempt with a wait derived from the header.
+func TestRetryableRequest_HonorsRetryAfter(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits++
+		if hits == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":"rate limited"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":0,"patentFileWrapperDataBag":[]}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	cfg.MaxRetries = 2
+	// 30s exponential floor vs. Retry-After: 1; if Retry-After isn't honored
+	// the test would wait ~30s (and exceed the test timeout). The big gap
+	// keeps the upper-bound check robust on contended CI.
+	cfg.RetryDelay = 30 * time.Second
+	cfg.Timeout = 10 * time.Second
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	start := time.Now()
+	_, err = client.SearchPatents(context.Background(), "x", 0, 1)
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("SearchPatents: %v", err)
+	}
+	if hits != 2 {
+		t.Errorf("expected 2 server hits, got %d", hits)
+	}
+	if elapsed > 5*time.Second {
+		t.Errorf("retry waited %v; Retry-After: 1 should beat the 30s exponential floor", elapsed)
+	}
+	if elapsed < 900*time.Millisecond {
+		t.Errorf("retry waited only %v; expected ~1s from Retry-After: 1", elapsed)
+	}
+}
+
+// TestSearchPatentsDownload_RetriesTruncatedBody serves the export once with
+// the connection dropped partway through the declared Content-Length, then in
+// full, and expects the retry to return the complete body.
+func TestSearchPatentsDownload_RetriesTruncatedBody(t *testing.T) {
+	full := []byte("applicationNumberText,inventionTitle\n17248024,Protected anode\n18863279,Compound\n")
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if hits.Add(1) == 1 {
+			conn, buf, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("hijack: %v", err)
+				return
+			}
+			fmt.Fprintf(buf, "HTTP/1.1 200 OK\r\nContent-Type: text/csv\r\nContent-Length: %d\r\n\r\n", len(full))
+			_, _ = buf.Write(full[:len(full)/2])
+			_ = buf.Flush()
+			_ = conn.Close()
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		_, _ = w.Write(full)
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	cfg.MaxRetries = 2
+	cfg.RetryDelay = 10 * time.Millisecond
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	got, err := client.SearchPatentsDownload(context.Background(), generated.PatentDownloadRequest{Q: StringPtr("x")})
+	if err != nil {
+		t.Fatalf("SearchPatentsDownload: %v", err)
+	}
+	if !bytes.Equal(got, full) {
+		t.Errorf("body = %q, want %q", got, full)
+	}
+	if n := hits.Load(); n != 2 {
+		t.Errorf("server hits = %d, want 2 (one truncated, one retry)", n)
+	}
+}
+
+func TestCheckBodyLength(t *testing.T) {
+	if err := checkBodyLength(&http.Response{StatusCode: 200, ContentLength: 3}, []byte("abc")); err != nil {
+		t.Errorf("matching length: unexpected error %v", err)
+	}
+	if err := checkBodyLength(&http.Response{StatusCode: 200, ContentLength: -1}, []byte("abc")); err != nil {
+		t.Errorf("unknown length: unexpected error %v", err)
+	}
+	err := checkBodyLength(&http.Response{StatusCode: 200, ContentLength: 10}, []byte("abc"))
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !apiErr.Truncated {
+		t.Fatalf("short body: got %v, want truncated *APIError", err)
+	}
+	if !apiErr.IsRetryable() {
+		t.Error("truncated body should be retryable")
+	}
+	if !isRetryableError(fmt.Errorf("reading body: %w", io.ErrUnexpectedEOF)) {
+		t.Error("io.ErrUnexpectedEOF should be retryable")
+	}
+}
+
+func TestSearchPetitionsByRule(t *testing.T) {
+	var gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/petition/decisions/search" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var req generated.PetitionDecisionSearchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		if req.Q != nil {
+			gotQuery = *req.Q
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":1,"petitionDecisionDataBag":[{
+			"petitionDecisionRecordIdentifier":"9dc6b94a-afa0-5e66-beef-f26fa80992b8",
+			"petitionIssueConsideredTextBag":["Revival of an abandoned application"],
+			"ruleBag":["37 CFR 1.137(a)","37 CFR 1.137(b)(1)"],
+			"statuteBag":["35 USC 27"]}]}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	ctx := context.Background()
+
+	res, err := client.SearchPetitionsByRule(ctx, "37 CFR 1.137(a)", 0, 10)
+	if err != nil {
+		t.Fatalf("SearchPetitionsByRule: %v", err)
+	}
+	if want := `ruleBag:"37 CFR 1.137(a)"`; gotQuery != want {
+		t.Errorf("query = %q, want %q", gotQuery, want)
+	}
+	if res.PetitionDecisionDataBag == nil || len(*res.PetitionDecisionDataBag) != 1 {
+		t.Fatalf("expected one decision, got %+v", res.PetitionDecisionDataBag)
+	}
+	rules := (*res.PetitionDecisionDataBag)[0].RuleBag
+	if rules == nil || (*rules)[0] != "37 CFR 1.137(a)" {
+		t.Errorf("ruleBag = %v, want the revival rule", rules)
+	}
+
+	if _, err := client.SearchPetitionsByRule(ctx, "35 USC 27", 0, 10); err != nil {
+		t.Fatalf("SearchPetitionsByRule(statute): %v", err)
+	}
+	if want := `statuteBag:"35 USC 27"`; gotQuery != want {
+		t.Errorf("statute query = %q, want %q", gotQuery, want)
+	}
+
+	if _, err := client.SearchPetitionsByRule(ctx, "  ", 0, 10); err == nil {
+		t.Error("expected error for empty rule")
+	}
+}
+
+func TestSearchPetitionsAdvanced(t *testing.T) {
+	var got generated.PetitionDecisionSearchRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/petition/decisions/search" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":1,"petitionDecisionDataBag":[{"decisionTypeCodeDescriptionText":"DENIED"}]}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	desc := generated.PetitionDecisionSortOrderDesc
+	res, err := client.SearchPetitionsAdvanced(context.Background(), generated.PetitionDecisionSearchRequest{
+		Q: StringPtr("revival"),
+		Filters: &[]generated.PetitionDecisionFilter{
+			{Name: StringPtr("decisionTypeCodeDescriptionText"), Value: &[]string{"DENIED"}},
+		},
+		RangeFilters: &[]generated.PetitionDecisionRange{
+			{Field: StringPtr("petitionMailDate"), ValueFrom: StringPtr("2020-01-01"), ValueTo: StringPtr("2020-12-31")},
+		},
+		Sort:       &[]generated.PetitionDecisionSort{{Field: StringPtr("petitionMailDate"), Order: &desc}},
+		Pagination: &generated.Pagination{Offset: Int32Ptr(0), Limit: Int32Ptr(25)},
+	})
+	if err != nil {
+		t.Fatalf("SearchPetitionsAdvanced: %v", err)
+	}
+	if res.Count == nil || *res.Count != 1 {
+		t.Errorf("count = %v, want 1", res.Count)
+	}
+	if got.Filters == nil || (*got.Filters)[0].Value == nil || (*(*got.Filters)[0].Value)[0] != "DENIED" {
+		t.Errorf("filters = %+v, want the decision type filter", got.Filters)
+	}
+	if got.RangeFilters == nil || *(*got.RangeFilters)[0].ValueTo != "2020-12-31" {
+		t.Errorf("rangeFilters = %+v, want the 2020 mail date range", got.RangeFilters)
+	}
+	if got.Sort == nil || *(*got.Sort)[0].Order != desc || *got.Pagination.Limit != 25 {
+		t.Errorf("sort or pagination not passed through: %+v, %+v", got.Sort, got.Pagination)
+	}
+}
+
+func TestGetPetitionsForApplication(t *testing.T) {
+	const total = 150
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req generated.PetitionDecisionSearchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		queries = append(queries, *req.Q)
+		offset, limit := int(*req.Pagination.Offset), int(*req.Pagination.Limit)
+		var page []generated.PetitionDecision
+		for i := offset; i < total && i < offset+limit; i++ {
+			page = append(page, generated.PetitionDecision{ApplicationNumberText: StringPtr("16123456")})
+		}
+		count := total
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(generated.PetitionDecisionResponseBag{Count: &count, PetitionDecisionDataBag: &page})
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	res, err := client.GetPetitionsForApplication(context.Background(), " 16123456 ")
+	if err != nil {
+		t.Fatalf("GetPetitionsForApplication: %v", err)
+	}
+	if len(queries) != 2 {
+		t.Errorf("made %d searches, want 2 pages", len(queries))
+	}
+	if want := `applicationNumberText:"16123456"`; queries[0] != want {
+		t.Errorf("query = %q, want %q", queries[0], want)
+	}
+	if res.Count == nil || *res.Count != total || len(*res.PetitionDecisionDataBag) != total {
+		t.Errorf("got count %v and %d decisions, want %d", res.Count, len(*res.PetitionDecisionDataBag), total)
+	}
+
+	if _, err := client.GetPetitionsForApplication(context.Background(), ""); err == nil {
+		t.Error("expected error for empty application number")
+	}
+}
+
+func TestSearchPetitionsAll(t *testing.T) {
+	const total = 7
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		var req generated.PetitionDecisionSearchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		offset, limit := int(*req.Pagination.Offset), int(*req.Pagination.Limit)
+		page := []generated.PetitionDecision{}
+		for i := offset; i < total && i < offset+limit; i++ {
+			page = append(page, generated.PetitionDecision{PetitionDecisionRecordIdentifier: StringPtr(fmt.Sprint(i))})
+		}
+		count := total
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(generated.PetitionDecisionResponseBag{Count: &count, PetitionDecisionDataBag: &page})
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	var ids []string
+	for d, err := range client.SearchPetitionsAll(context.Background(), "revival", 3) {
+		if err != nil {
+			t.Fatalf("SearchPetitionsAll: %v", err)
+		}
+		ids = append(ids, *d.PetitionDecisionRecordIdentifier)
+	}
+	if strings.Join(ids, ",") != "0,1,2,3,4,5,6" {
+		t.Errorf("ids = %v, want 0..6 in order", ids)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("made %d searches, want 3 pages", got)
+	}
+
+	// Breaking out stops further page fetches.
+	calls.Store(0)
+	for range client.SearchPetitionsAll(context.Background(), "revival", 3) {
+		break
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("made %d searches after break, want 1", got)
+	}
+
+	// A cancelled context is yielded as the only error.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var errs int
+	for _, err := range client.SearchPetitionsAll(ctx, "revival", 3) {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("err = %v, want context.Canceled", err)
+		}
+		errs++
+	}
+	if errs != 1 {
+		t.Errorf("yielded %d errors, want 1", errs)
+	}
+}
+
+func TestGetPatentDocumentsFiltered(t *testing.T) {
+	var gotQuery url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/patent/applications/16123456/documents" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotQuery = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"documentBag":[
+			{"documentIdentifier":"A","documentCode":"CTNF","documentDirectionCategory":"OUTGOING"},
+			{"documentIdentifier":"B","documentCode":"REM","directionCategory":"INCOMING"},
+			{"documentIdentifier":"C","documentCode":"CTFR","documentDirectionCategory":"OUTGOING"}]}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
 	}
-	var out map[string]any
-	err := readJSONResponse(resp, &out)
-	var apiErr *APIError
-	if !errors.As(err, &apiErr) || !apiErr.Empty {
-		t.Fatalf("empty 200: got %v (%T), want empty-body APIError", err, err)
+	ctx := context.Background()
+
+	bag, err := client.GetPatentDocumentsFiltered(ctx, "16123456", DocumentFilter{
+		Codes:     []string{"CTNF", "CTFR"},
+		Direction: "outgoing",
+		From:      time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC),
+		To:        time.Date(2023, time.June, 30, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("GetPatentDocumentsFiltered: %v", err)
 	}
-	if strings.Contains(err.Error(), "unexpected end of JSON") {
-		t.Errorf("should not surface the opaque json message: %q", err.Error())
+	if got := gotQuery.Get("documentCodes"); got != "CTNF,CTFR" {
+		t.Errorf("documentCodes = %q, want CTNF,CTFR", got)
 	}
-}
-
-// TestIsRetryableError_ContextErrors verifies that context cancellation and
-// deadline-exceeded are treated as caller intent, not transient network
-// failures. context.DeadlineExceeded satisfies net.Error.Timeout(), so without
-// the short-circuit it would be misclassified as a retryable timeout.
-func TestIsRetryableError_ContextErrors(t *testing.T) {
-	if isRetryableError(context.Canceled) {
-		t.Error("context.Canceled must not be retryable")
+	if gotQuery.Get("officialDateFrom") != "2022-01-01" || gotQuery.Get("officialDateTo") != "2023-06-30" {
+		t.Errorf("date range = %s..%s", gotQuery.Get("officialDateFrom"), gotQuery.Get("officialDateTo"))
 	}
-	if isRetryableError(context.DeadlineExceeded) {
-		t.Error("context.DeadlineExceeded must not be retryable")
+	var ids []string
+	for _, d := range *bag.DocumentBag {
+		ids = append(ids, derefStr(d.DocumentIdentifier))
 	}
-	wrapped := fmt.Errorf("get failed: %w", context.DeadlineExceeded)
-	if isRetryableError(wrapped) {
-		t.Error("wrapped context.DeadlineExceeded must not be retryable")
+	if strings.Join(ids, ",") != "A,C" {
+		t.Errorf("kept documents %v, want the outgoing A and C", ids)
+	}
+
+	// A zero filter sends no parameters and keeps everything.
+	bag, err = client.GetPatentDocumentsFiltered(ctx, "16123456", DocumentFilter{})
+	if err != nil {
+		t.Fatalf("GetPatentDocumentsFiltered(zero): %v", err)
+	}
+	if len(gotQuery) != 0 || len(*bag.DocumentBag) != 3 {
+		t.Errorf("zero filter: query %v, %d documents; want none and 3", gotQuery, len(*bag.DocumentBag))
 	}
 }
 
-// TestRetryableRequest_DeadlineExceededNotRetried ensures a per-request
-// deadline that fires does not trigger the retry loop: the server is hit once,
-// then the deadline-exceeded error propagates immediately.
-func TestRetryableRequest_DeadlineExceededNotRetried(t *testing.T) {
-	var hits atomic.Int32
-	release := make(chan struct{})
-	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
-		hits.Add(1)
-		// Block past the client's deadline; release on test teardown so the
-		// handler goroutine exits and server.Close() doesn't hang.
-		select {
-		case <-release:
-		case <-r.Context().Done():
+func TestSearchAssignments(t *testing.T) {
+	var gotReq generated.PatentSearchRequest
+	found := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/patent/applications/search" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&gotReq); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if !found {
+			// ODP answers an empty search with 404.
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":404,"message":"No matching records found"}`))
+			return
 		}
+		_, _ = w.Write([]byte(`{"count":2,"patentFileWrapperDataBag":[
+			{"applicationNumberText":"15000001","assignmentBag":[{
+				"assigneeBag":[{"assigneeNameText":"SAMSUNG ELECTRONICS CO., LTD","assigneeAddress":{"cityName":"SUWON-SI","postalCode":"16677"}}],
+				"assignorBag":[{"assignorName":"HEO, JIN-PIL","executionDate":"2016-01-05"}],
+				"assignmentRecordedDate":"2016-04-19",
+				"assignmentMailedDate":"2016-04-20",
+				"conveyanceText":"ASSIGNMENT OF ASSIGNORS INTEREST (SEE DOCUMENT FOR DETAILS).",
+				"reelAndFrameNumber":"038323/0190",
+				"pageTotalQuantity":8,
+				"imageAvailableStatusCode":true,
+				"attorneyDocketNumber":"SEC-1234",
+				"correspondenceAddress":{"correspondentNameText":"MUIR PATENT LAW, PLLC"}}]},
+			{"applicationNumberText":"15000002"}]}`))
 	}))
 	defer server.Close()
-	defer close(release)
 
 	cfg := DefaultConfig()
 	cfg.BaseURL = server.URL
 	cfg.APIKey = "test"
-	cfg.MaxRetries = 3
-	cfg.Timeout = 100 * time.Millisecond
 	client, err := NewClient(cfg)
 	if err != nil {
 		t.Fatalf("NewClient: %v", err)
 	}
+	ctx := context.Background()
 
-	_, err = client.SearchPatents(context.Background(), "x", 0, 1)
-	if err == nil {
-		t.Fatal("expected deadline error, got nil")
+	const query = `assignmentBag.assigneeBag.assigneeNameText:"SAMSUNG ELECTRONICS CO., LTD"`
+	results, total, err := client.SearchAssignments(ctx, query, 0, 25)
+	if err != nil {
+		t.Fatalf("SearchAssignments: %v", err)
 	}
-	if got := hits.Load(); got != 1 {
-		t.Errorf("expected 1 server hit (deadline not retried), got %d", got)
+	if gotReq.Q == nil || *gotReq.Q != query {
+		t.Errorf("q = %v, want %q", gotReq.Q, query)
+	}
+	if gotReq.Fields == nil || len(*gotReq.Fields) != 2 || (*gotReq.Fields)[1] != "assignmentBag" {
+		t.Errorf("fields = %v, want the assignment projection", gotReq.Fields)
+	}
+	if total != 2 || len(results) != 2 {
+		t.Fatalf("got %d results (total %d), want 2", len(results), total)
+	}
+	if results[0].ApplicationNumber != "15000001" || len(results[0].Assignments) != 1 {
+		t.Fatalf("first result = %+v", results[0])
+	}
+	a := results[0].Assignments[0]
+	if a.ReelNumber != 38323 || a.FrameNumber != 190 || a.RecordedDate != "2016-04-19" || a.MailedDate != "2016-04-20" {
+		t.Errorf("reel/frame or dates = %+v", a)
+	}
+	if !strings.HasPrefix(a.Conveyance, "ASSIGNMENT OF ASSIGNORS INTEREST") {
+		t.Errorf("Conveyance = %q", a.Conveyance)
+	}
+	if a.PageCount != 8 || !a.ImageAvailable || a.AttorneyDocketNumber != "SEC-1234" || a.CorrespondentName != "MUIR PATENT LAW, PLLC" {
+		t.Errorf("recordation details = %+v", a)
+	}
+	if len(a.Assignees) != 1 || a.Assignees[0].PostalCode != "16677" || len(a.Assignors) != 1 {
+		t.Errorf("parties = %+v / %+v", a.Assignees, a.Assignors)
+	}
+	if results[1].Assignments == nil || len(results[1].Assignments) != 0 {
+		t.Errorf("application without assignments = %+v, want an empty chain", results[1])
+	}
+
+	found = false
+	results, total, err = client.SearchAssignments(ctx, `assignmentBag.reelNumber:1`, 0, 25)
+	if err != nil || total != 0 || results == nil || len(results) != 0 {
+		t.Errorf("no matches = %v, %d, %v; want empty, 0, nil", results, total, err)
 	}
 }
 
-func TestRetryableRequest_AboveCapSurfaces(t *testing.T) {
-	hits := 0
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		hits++
-		w.Header().Set("Retry-After", "600")
-		w.WriteHeader(http.StatusTooManyRequests)
-		_, _ = w.Write([]byte(`{"error":"rate limited"}`))
+func TestEnrichWithStatusDescriptions(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/patent/status-codes" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		hits.Add(1)
+		// A full first page of filler codes, then the codes under test.
+		var bag []map[string]any
+		if r.URL.Query().Get("offset") == "0" {
+			for code := 1000; len(bag) < statusCodePageSize; code++ {
+				bag = append(bag, map[string]any{"applicationStatusCode": code, "applicationStatusDescriptionText": "filler"})
+			}
+		} else {
+			bag = []map[string]any{
+				{"applicationStatusCode": 17, "applicationStatusDescriptionText": "Sent to Classification contractor"},
+				{"applicationStatusCode": 150, "applicationStatusDescriptionText": "Patented Case"},
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"count": statusCodePageSize + 2, "statusCodeBag": bag})
 	}))
 	defer server.Close()
 
 	cfg := DefaultConfig()
 	cfg.BaseURL = server.URL
 	cfg.APIKey = "test"
-	cfg.MaxRetries = 3
-	cfg.MaxRetryAfter = 60 * time.Second // explicit; verifies the cap is honored
-	cfg.Timeout = 10 * time.Second
 	client, err := NewClient(cfg)
 	if err != nil {
 		t.Fatalf("NewClient: %v", err)
 	}
+	wrapper := func(code int, desc string) *PatentFileWrapper {
+		m := &generated.ApplicationMetaData{ApplicationStatusCode: IntPtr(code)}
+		if desc != "" {
+			m.ApplicationStatusDescriptionText = StringPtr(desc)
+		}
+		return &PatentFileWrapper{ApplicationMetaData: m}
+	}
+	wrappers := []*PatentFileWrapper{
+		wrapper(17, ""),
+		wrapper(150, "Patented Case (from API)"),
+		wrapper(999, ""),
+		{},
+		nil,
+	}
 
-	_, err = client.SearchPatents(context.Background(), "x", 0, 1)
-	if err == nil {
-		t.Fatal("expected error, got nil")
+	if err := client.EnrichWithStatusDescriptions(context.Background(), wrappers); err != nil {
+		t.Fatalf("EnrichWithStatusDescriptions: %v", err)
 	}
-	var apiErr *APIError
-	if !errors.As(err, &apiErr) {
-		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	if got := derefStr(wrappers[0].ApplicationMetaData.ApplicationStatusDescriptionText); got != "Sent to Classification contractor" {
+		t.Errorf("code 17 description = %q", got)
 	}
-	if apiErr.RetryAfter != 600*time.Second {
-		t.Errorf("RetryAfter = %v, want 600s", apiErr.RetryAfter)
+	if got := derefStr(wrappers[1].ApplicationMetaData.ApplicationStatusDescriptionText); got != "Patented Case (from API)" {
+		t.Errorf("existing description overwritten: %q", got)
 	}
-	if hits != 1 {
-		t.Errorf("expected 1 server hit (no retry above cap), got %d", hits)
+	if wrappers[2].ApplicationMetaData.ApplicationStatusDescriptionText != nil {
+		t.Error("unknown code should stay without a description")
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("status-code requests = %d, want 2 pages", got)
+	}
+
+	// The table is cached on the client.
+	if err := client.EnrichWithStatusDescriptions(context.Background(), []*PatentFileWrapper{wrapper(17, "")}); err != nil {
+		t.Fatalf("second call: %v", err)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("status-code requests after second call = %d, want the cached table used", got)
 	}
 }
 
-// TestRetryableRequest_HonorsRetryAfter exercises the full retry path: a
-// server returns 429 with Retry-After: 1 once, then 200, and the client
-// succeeds on the second attempt with a wait derived from the header.
-func TestRetryableRequest_HonorsRetryAfter(t *testing.T) {
-	hits := 0
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		hits++
-		if hits == 1 {
-			w.Header().Set("Retry-After", "1")
-			w.WriteHeader(http.StatusTooManyRequests)
-			_, _ = w.Write([]byte(`{"error":"rate limited"}`))
-			return
+// TestStatusCodeTable_CallerCancelIsolated checks that callers arriving
+// during a load of the status-code table share it, and that one caller
+// giving up neither blocks nor fails the others.
+func TestStatusCodeTable_CallerCancelIsolated(t *testing.T) {
+	var hits atomic.Int32
+	stalled, release := make(chan struct{}), make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			close(stalled)
+			<-release
 		}
 		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(`{"count":0,"patentFileWrapperDataBag":[]}`))
+		_, _ = w.Write([]byte(`{"count":1,"statusCodeBag":[{"applicationStatusCode":150,"applicationStatusDescriptionText":"Patented Case"}]}`))
 	}))
 	defer server.Close()
 
 	cfg := DefaultConfig()
 	cfg.BaseURL = server.URL
 	cfg.APIKey = "test"
-	cfg.MaxRetries = 2
-	// 30s exponential floor vs. Retry-After: 1; if Retry-After isn't honored
-	// the test would wait ~30s (and exceed the test timeout). The big gap
-	// keeps the upper-bound check robust on contended CI.
-	cfg.RetryDelay = 30 * time.Second
-	cfg.Timeout = 10 * time.Second
 	client, err := NewClient(cfg)
 	if err != nil {
 		t.Fatalf("NewClient: %v", err)
 	}
 
-	start := time.Now()
-	_, err = client.SearchPatents(context.Background(), "x", 0, 1)
-	elapsed := time.Since(start)
-	if err != nil {
-		t.Fatalf("SearchPatents: %v", err)
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := client.statusCodeTable(firstCtx)
+		firstErr <- err
+	}()
+	<-stalled
+
+	type result struct {
+		table map[int]string
+		err   error
 	}
-	if hits != 2 {
-		t.Errorf("expected 2 server hits, got %d", hits)
+	second := make(chan result, 1)
+	go func() {
+		table, err := client.statusCodeTable(context.Background())
+		second <- result{table, err}
+	}()
+
+	// The first caller gives up; it returns at once, the load carries on.
+	cancelFirst()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled caller err = %v, want context.Canceled", err)
 	}
-	if elapsed > 5*time.Second {
-		t.Errorf("retry waited %v; Retry-After: 1 should beat the 30s exponential floor", elapsed)
+	close(release)
+
+	r := <-second
+	if r.err != nil || r.table[150] != "Patented Case" {
+		t.Errorf("second caller = %v, %v; want the shared table", r.table, r.err)
 	}
-	if elapsed < 900*time.Millisecond {
-		t.Errorf("retry waited only %v; expected ~1s from Retry-After: 1", elapsed)
+	if got := hits.Load(); got != 1 {
+		t.Errorf("status-code requests = %d, want one shared load", got)
+	}
+}
+
+func TestQuoteQueryValue(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"37 CFR 1.137(a)", `"37 CFR 1.137(a)"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{`a\b`, `"a\\b"`},
+	}
+	for _, tt := range tests {
+		if got := quoteQueryValue(tt.in); got != tt.want {
+			t.Errorf("quoteQueryValue(%q) = %s, want %s", tt.in, got, tt.want)
+		}
 	}
 }
 
@@ -322,6 +1231,61 @@ func TestDefaultConfig(t *testing.T) {
 	}
 }
 
+func TestDefaultConfig_APIKeyFromEnv(t *testing.T) {
+	t.Setenv(APIKeyEnv, "env-key")
+	if got := DefaultConfig().APIKey; got != "env-key" {
+		t.Errorf("APIKey = %q, want it read from %s", got, APIKeyEnv)
+	}
+
+	// An explicit key wins over the environment.
+	client, err := NewClientWithOptions(WithAPIKey("explicit-key"))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions: %v", err)
+	}
+	if client.config.APIKey != "explicit-key" {
+		t.Errorf("APIKey = %q, want the explicit key", client.config.APIKey)
+	}
+
+	t.Setenv(APIKeyEnv, "")
+	if got := DefaultConfig().APIKey; got != "" {
+		t.Errorf("APIKey = %q with %s empty", got, APIKeyEnv)
+	}
+}
+
+// TestGetPatentAdjustment_SequencePrecision checks that history sequence
+// numbers keep values a float32 would round, such as 16777217.5.
+func TestGetPatentAdjustment_SequencePrecision(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":1,"patentFileWrapperDataBag":[{"applicationNumberText":"17123456",
+			"patentTermAdjustmentData":{"adjustmentTotalQuantity":12,"overlappingDayQuantity":3,
+			"patentTermAdjustmentHistoryDataBag":[{"eventDate":"2023-07-11","ptaPTECode":"PTA",
+			"eventSequenceNumber":16777217.5,"originatingEventSequenceNumber":123456.789}]}}]}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	result, err := client.GetPatentAdjustment(context.Background(), "17123456")
+	if err != nil {
+		t.Fatalf("GetPatentAdjustment: %v", err)
+	}
+	if result.TotalAdjustmentDays != 12 || result.OverlapDays != 3 {
+		t.Errorf("total, overlap = %d, %d; want 12, 3", result.TotalAdjustmentDays, result.OverlapDays)
+	}
+	if len(result.History) != 1 {
+		t.Fatalf("History = %d events, want 1", len(result.History))
+	}
+	if h := result.History[0]; h.SequenceNumber != 16777217.5 || h.OriginatingSequenceNumber != 123456.789 {
+		t.Errorf("sequence numbers = %v, %v; want 16777217.5, 123456.789", h.SequenceNumber, h.OriginatingSequenceNumber)
+	}
+}
+
 // TestClientWithActualResponses tests all client methods with actual API response structures
 func TestClientWithActualResponses(t *testing.T) {
 	// Create mock server with actual response structures
@@ -593,6 +1557,12 @@ func TestClientWithActualResponses(t *testing.T) {
 			}
 			_ = json.NewEncoder(w).Encode(response)
 
+		case "/api/v1/patent/applications/18555001":
+			// A pending application: no patentNumber yet.
+			_, _ = w.Write([]byte(`{"count":1,"patentFileWrapperDataBag":[{"applicationNumberText":"18555001",
+				"applicationMetaData":{"applicationStatusDescriptionText":"Docketed New Case - Ready for Examination",
+				"inventionTitle":"PENDING APPLICATION"}}]}`))
+
 		case "/api/v1/patent/applications/17123456":
 			// Actual response from GetPatent
 			response := map[string]interface{}{
@@ -1852,6 +2822,24 @@ func TestClientWithActualResponses(t *testing.T) {
 		}
 	})
 
+	t.Run("GrantNumberForApplication", func(t *testing.T) {
+		number, granted, err := client.GrantNumberForApplication(ctx, "17123456")
+		if err != nil {
+			t.Fatalf("GrantNumberForApplication failed: %v", err)
+		}
+		if !granted || number != "11696966" {
+			t.Errorf("GrantNumberForApplication(17123456) = %q, %v, want 11696966, true", number, granted)
+		}
+
+		number, granted, err = client.GrantNumberForApplication(ctx, "18555001")
+		if err != nil {
+			t.Fatalf("GrantNumberForApplication(pending) failed: %v", err)
+		}
+		if granted || number != "" {
+			t.Errorf("GrantNumberForApplication(18555001) = %q, %v, want \"\", false", number, granted)
+		}
+	})
+
 	t.Run("GetPatentAdjustment", func(t *testing.T) {
 		result, err := client.GetPatentAdjustment(ctx, "17123456")
 		if err != nil {
@@ -1872,6 +2860,27 @@ func TestClientWithActualResponses(t *testing.T) {
 		if result.BDelays != 0 {
 			t.Errorf("BDelays = %d, want 0", result.BDelays)
 		}
+		if got := result.ComputedAdjustment(); got != 238 {
+			t.Errorf("ComputedAdjustment() = %d, want 238", got)
+		}
+		if result.HasDiscrepancy() {
+			t.Errorf("HasDiscrepancy() = true for a consistent record: %+v", result)
+		}
+		if len(result.History) != 2 {
+			t.Fatalf("History = %d events, want 2", len(result.History))
+		}
+		if got := result.History[0].SequenceNumber; got != 64.5 {
+			t.Errorf("History[0].SequenceNumber = %v, want 64.5", got)
+		}
+		if got := result.History[0].OriginatingSequenceNumber; got != 0.5 {
+			t.Errorf("History[0].OriginatingSequenceNumber = %v, want 0.5", got)
+		}
+		if got := result.History[1].SequenceNumber; got != 64 {
+			t.Errorf("History[1].SequenceNumber = %v, want 64", got)
+		}
+		if result.History[0].Code != "PTA" || result.History[0].Date != "2023-07-11" {
+			t.Errorf("History[0] = %+v", result.History[0])
+		}
 	})
 
 	t.Run("GetPatentContinuity", func(t *testing.T) {
@@ -1915,6 +2924,16 @@ func TestClientWithActualResponses(t *testing.T) {
 		}
 	})
 
+	t.Run("GetPatentDocumentCodes", func(t *testing.T) {
+		codes, err := client.GetPatentDocumentCodes(ctx, "17123456")
+		if err != nil {
+			t.Fatalf("GetPatentDocumentCodes failed: %v", err)
+		}
+		if codes["EGRANT.PDF"] != 1 || codes["EGRANT.NTF"] != 1 || len(codes) != 2 {
+			t.Errorf("codes = %v, want EGRANT.PDF:1 EGRANT.NTF:1", codes)
+		}
+	})
+
 	t.Run("GetStatusCodes", func(t *testing.T) {
 		result, err := client.GetStatusCodes(ctx)
 		if err != nil {
@@ -1960,6 +2979,41 @@ func TestClientWithActualResponses(t *testing.T) {
 		}
 	})
 
+	t.Run("GetBulkProductSummary", func(t *testing.T) {
+		summary, err := client.GetBulkProductSummary(ctx, "PTGRXML")
+		if err != nil {
+			t.Fatalf("GetBulkProductSummary failed: %v", err)
+		}
+		if summary.ProductID != "PTGRXML" {
+			t.Errorf("ProductID = %q, want PTGRXML", summary.ProductID)
+		}
+		if summary.Frequency != "WEEKLY" {
+			t.Errorf("Frequency = %q, want WEEKLY", summary.Frequency)
+		}
+		if summary.FileCount != 1267 {
+			t.Errorf("FileCount = %d, want 1267", summary.FileCount)
+		}
+		if summary.TotalSize != 120319343938 {
+			t.Errorf("TotalSize = %d, want 120319343938", summary.TotalSize)
+		}
+		if summary.FromDate != "2002-01-01" || summary.ToDate != "2025-09-23" {
+			t.Errorf("date range = %s..%s, want 2002-01-01..2025-09-23", summary.FromDate, summary.ToDate)
+		}
+		if summary.Title == "" {
+			t.Error("Title is empty")
+		}
+	})
+
+	t.Run("GetBulkFileURL", func(t *testing.T) {
+		signed, err := client.GetBulkFileURL(ctx, "PTGRXML", "2025/ipg250916.zip")
+		if err != nil {
+			t.Fatalf("GetBulkFileURL failed: %v", err)
+		}
+		if !strings.HasPrefix(signed, "https://data.uspto.gov/files/PTGRXML/2025/ipg250916.zip?Expires=") {
+			t.Errorf("GetBulkFileURL = %q, want the signed Location", signed)
+		}
+	})
+
 	t.Run("SearchPetitions", func(t *testing.T) {
 		result, err := client.SearchPetitions(ctx, "revival", 0, 10)
 		if err != nil {
@@ -1968,6 +3022,26 @@ func TestClientWithActualResponses(t *testing.T) {
 		if result == nil {
 			t.Fatal("Expected result, got nil")
 		}
+		petitions := PetitionsFromSearch(result)
+		if len(petitions) != 1 {
+			t.Fatalf("PetitionsFromSearch returned %d records, want 1", len(petitions))
+		}
+		p := petitions[0]
+		if p.PetitionTypeCode() != 502 {
+			t.Errorf("PetitionTypeCode() = %d, want 502", p.PetitionTypeCode())
+		}
+		if derefStr(p.DecisionTypeCode) != "C" || p.DecisionType() != "DENIED" {
+			t.Errorf("decision = %q/%q, want C/DENIED", derefStr(p.DecisionTypeCode), p.DecisionType())
+		}
+		if issues := p.Issues(); len(issues) != 1 || issues[0] != "Revival of an abandoned application" {
+			t.Errorf("Issues() = %v, want [Revival of an abandoned application]", issues)
+		}
+		if d, ok := p.MailDate(); !ok || !d.Equal(time.Date(2020, 5, 15, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("MailDate() = %v, %v; want 2020-05-15", d, ok)
+		}
+		if d, ok := p.LastIngestion(); !ok || !d.Equal(time.Date(2025, 7, 16, 19, 49, 16, 0, time.UTC)) {
+			t.Errorf("LastIngestion() = %v, %v; want 2025-07-16T19:49:16Z", d, ok)
+		}
 		// Basic validation - check that we got data
 		jsonData, _ := json.Marshal(result)
 		if len(jsonData) < 10 {
@@ -1997,9 +3071,18 @@ func TestClientWithActualResponses(t *testing.T) {
 		if a.ReelFrame != "038323/0190" {
 			t.Errorf("ReelFrame = %q, want %q", a.ReelFrame, "038323/0190")
 		}
+		if a.ReelNumber != 38323 || a.FrameNumber != 190 {
+			t.Errorf("ReelNumber/FrameNumber = %d/%d, want 38323/190", a.ReelNumber, a.FrameNumber)
+		}
+		if a.Conveyance == "" || a.RecordedDate != "2016-04-19" {
+			t.Errorf("Conveyance = %q, RecordedDate = %q", a.Conveyance, a.RecordedDate)
+		}
 		if len(a.Assignors) == 0 {
 			t.Error("Assignors should not be empty")
 		}
+		if a.PageCount != 8 || a.ImageAvailable || a.CorrespondentName != "MUIR PATENT LAW, PLLC" {
+			t.Errorf("PageCount = %d, ImageAvailable = %v, CorrespondentName = %q", a.PageCount, a.ImageAvailable, a.CorrespondentName)
+		}
 	})
 
 	t.Run("GetPatentAssociatedDocuments", func(t *testing.T) {
@@ -2118,6 +3201,19 @@ func TestClientWithActualResponses(t *testing.T) {
 		}
 	})
 
+	t.Run("GetPetitionDecisionRaw", func(t *testing.T) {
+		raw, err := client.GetPetitionDecisionRaw(ctx, "test-petition-id", true)
+		if err != nil {
+			t.Fatalf("GetPetitionDecisionRaw failed: %v", err)
+		}
+		if !strings.Contains(string(raw), `"decisionTypeCodeDescriptionText"`) {
+			t.Errorf("raw body missing decisionTypeCodeDescriptionText: %s", raw)
+		}
+		if !json.Valid(raw) {
+			t.Error("raw body is not valid JSON")
+		}
+	})
+
 	t.Run("SearchPetitionsDownload", func(t *testing.T) {
 		req := generated.PetitionDecisionDownloadRequest{
 			Q: StringPtr("revival"),
@@ -2440,4 +3536,21 @@ func TestClientWithActualResponses(t *testing.T) {
 		}
 	})
 
+	t.Run("GetGrantXMLURL and GetApplicationXMLURL", func(t *testing.T) {
+		// A granted application has both; the pgpub is reachable despite the grant.
+		grant, err := client.GetGrantXMLURL(ctx, "17999999")
+		if err != nil || !strings.HasSuffix(grant, "/17999999_12345678.xml") {
+			t.Errorf("GetGrantXMLURL = %q, %v; want the grant XML", grant, err)
+		}
+		app, err := client.GetApplicationXMLURL(ctx, "17999999")
+		if err != nil || !strings.HasSuffix(app, "/17999999_20230001234.xml") {
+			t.Errorf("GetApplicationXMLURL = %q, %v; want the pgpub XML", app, err)
+		}
+		if _, err := client.GetGrantXMLURL(ctx, "18500001"); err == nil || !strings.Contains(err.Error(), "no grant XML URL") {
+			t.Errorf("GetGrantXMLURL(pending) error = %v", err)
+		}
+		if _, err := client.GetApplicationXMLURL(ctx, "18000001"); err == nil || !strings.Contains(err.Error(), "no pre-grant publication XML URL") {
+			t.Errorf("GetApplicationXMLURL(no XML) error = %v", err)
+		}
+	})
 }
@@ -0,0 +1,156 @@
+package odp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/patent-dev/uspto-odp/generated"
+)
+
+// TestClient_ConcurrentUse drives one Client from many goroutines through a
+// plain endpoint, the status-code cache and the rate limiter at once. It
+// asserts only that every call succeeds; its real check is `go test -race`
+// (make test), which fails on any unsynchronized shared state.
+func TestClient_ConcurrentUse(t *testing.T) {
+	var statusHits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/patent/status-codes" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		statusHits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"count": 2,
+			"statusCodeBag": []map[string]any{
+				{"applicationStatusCode": 17, "applicationStatusDescriptionText": "Sent to Classification contractor"},
+				{"applicationStatusCode": 150, "applicationStatusDescriptionText": "Patented Case"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	cfg.RequestsPerSecond = 2000
+	cfg.Burst = 4
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	const workers = 32
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*workers)
+	for i := range workers {
+		wg.Go(func() {
+			if _, err := client.GetStatusCodes(ctx); err != nil {
+				errs <- err
+				return
+			}
+			w := &PatentFileWrapper{ApplicationMetaData: &generated.ApplicationMetaData{ApplicationStatusCode: IntPtr([]int{17, 150}[i%2])}}
+			if err := client.EnrichWithStatusDescriptions(ctx, []*PatentFileWrapper{w}); err != nil {
+				errs <- err
+				return
+			}
+			if w.ApplicationMetaData.ApplicationStatusDescriptionText == nil {
+				t.Errorf("worker %d: status not enriched", i)
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	// One GetStatusCodes per worker plus a single load of the cached table.
+	if got := statusHits.Load(); got != workers+1 {
+		t.Errorf("status-code requests = %d, want %d", got, workers+1)
+	}
+}
+
+func TestGetPatents(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		app := strings.TrimPrefix(r.URL.Path, "/api/v1/patent/applications/")
+		if app == "99999999" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		writeWrapperBag(w, app, "Title "+app)
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	numbers := []string{"17248024", "not a number", "16000001", "99999999", "15000011", "14643719"}
+	results, errs := client.GetPatents(context.Background(), numbers, 2)
+	if len(results) != len(numbers) || len(errs) != len(numbers) {
+		t.Fatalf("got %d results and %d errors for %d numbers", len(results), len(errs), len(numbers))
+	}
+	for i, n := range numbers {
+		switch n {
+		case "not a number", "99999999":
+			if errs[i] == nil || results[i] != nil {
+				t.Errorf("%s: result %v, err %v; want an error only", n, results[i], errs[i])
+			}
+		default:
+			if errs[i] != nil {
+				t.Errorf("%s: %v", n, errs[i])
+				continue
+			}
+			if got := derefStr((*results[i].PatentFileWrapperDataBag)[0].ApplicationNumberText); got != n {
+				t.Errorf("results[%d] is application %s, want %s", i, got, n)
+			}
+		}
+	}
+	if m := maxInFlight.Load(); m > 2 {
+		t.Errorf("%d requests in flight, want at most 2", m)
+	}
+
+	// The workers must not share a trace on ctx; `go test -race` checks it.
+	var trace ResolutionTrace
+	_, errs = client.GetPatents(WithResolutionTrace(context.Background(), &trace), numbers, 3)
+	if errs[0] != nil {
+		t.Errorf("traced GetPatents: %v", errs[0])
+	}
+	if trace.Input != "" || len(trace.Steps) != 0 {
+		t.Errorf("trace = %+v, want it untouched", trace)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, errs = client.GetPatents(ctx, numbers[:2], 0)
+	for i, err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("cancelled errs[%d] = %v, want context.Canceled", i, err)
+		}
+	}
+}
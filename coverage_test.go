@@ -27,7 +27,8 @@ var unwrappedPatterns = []struct {
 }{
 	// Body+response combo wrappers -- we always pass typed JSON (the non-WithBody form).
 	{"WithBodyWithResponse", "we use the typed JSON variant instead"},
-	// GET form of search endpoints (POST variants already wrapped).
+	// GET form of search endpoints (POST variants already wrapped). The patent
+	// applications GET search is wrapped by SearchPatentsGET and found by the scan.
 	{"SearchWithResponse", "we use the POST search variant"},
 	{"SearchDownloadWithResponse", "we use the POST search-download variant"},
 	// OA DSAPI raw form helpers -- we use *WithFormdataBody, not the WithFormdataBodyWithResponse one.
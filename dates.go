@@ -0,0 +1,41 @@
+package odp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// usptoDateLayouts are tried in order by ParseUSPTODate. Fractional seconds
+// after the seconds field are accepted by time.Parse without a layout of
+// their own.
+var usptoDateLayouts = []string{
+	"2006-01-02",               // filingDate, grantDate, eventDate, ...
+	"2006-01-02T15:04:05-0700", // officialDate: 2023-07-11T00:00:00.000-0400
+	time.RFC3339,               // 2023-07-11T00:00:00Z, 2023-07-11T00:00:00-04:00
+	"2006-01-02T15:04:05",      // lastIngestionDateTime, no zone
+	"2006-01-02 15:04:05",      // 2025-09-23 10:02:00
+	"20060102",                 // full-text XML
+}
+
+// ParseUSPTODate parses a date or timestamp in any of the formats the ODP
+// API and the full-text XML use: "2025-09-11", "2023-07-11T00:00:00.000-0400",
+// RFC 3339, "2025-07-16T19:49:16", "2025-09-23 10:02:00" and "20250911".
+// Values with a zone offset keep it; values without one are returned as
+// UTC. Surrounding whitespace is ignored.
+func ParseUSPTODate(s string) (time.Time, error) {
+	v := strings.TrimSpace(s)
+	for _, layout := range usptoDateLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized USPTO date %q", s)
+}
+
+// parseDate is ParseUSPTODate for the typed date accessors: ok is false
+// when s is empty or not a recognized date.
+func parseDate(s string) (time.Time, bool) {
+	t, err := ParseUSPTODate(s)
+	return t, err == nil
+}
@@ -0,0 +1,58 @@
+package odp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseUSPTODate(t *testing.T) {
+	edt := time.FixedZone("", -4*3600)
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{in: "2025-09-11", want: time.Date(2025, 9, 11, 0, 0, 0, 0, time.UTC)},
+		{in: " 2025-09-11\n", want: time.Date(2025, 9, 11, 0, 0, 0, 0, time.UTC)},
+		{in: "2023-07-11T00:00:00.000-0400", want: time.Date(2023, 7, 11, 0, 0, 0, 0, edt)},
+		{in: "2023-07-11T00:00:00-04:00", want: time.Date(2023, 7, 11, 0, 0, 0, 0, edt)},
+		{in: "2023-07-11T04:00:00Z", want: time.Date(2023, 7, 11, 4, 0, 0, 0, time.UTC)},
+		{in: "2025-07-16T19:49:16", want: time.Date(2025, 7, 16, 19, 49, 16, 0, time.UTC)},
+		{in: "2025-07-16T19:49:16.5", want: time.Date(2025, 7, 16, 19, 49, 16, 500000000, time.UTC)},
+		{in: "2025-09-23 10:02:00", want: time.Date(2025, 9, 23, 10, 2, 0, 0, time.UTC)},
+		{in: "20220101", want: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{in: "", wantErr: true},
+		{in: "09/11/2025", wantErr: true},
+		{in: "2025-13-01", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseUSPTODate(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseUSPTODate(%q) err = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseUSPTODate(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParsedDateAccessors(t *testing.T) {
+	m := &MetaDataResponse{FilingDate: "2021-01-05", GrantDate: ""}
+	if got, ok := m.ParsedFilingDate(); !ok || !got.Equal(time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ParsedFilingDate() = %v, %v", got, ok)
+	}
+	if _, ok := m.ParsedGrantDate(); ok {
+		t.Error("ParsedGrantDate() ok for an empty GrantDate")
+	}
+
+	d := FileWrapperDocument{OfficialDate: "2023-07-11T00:00:00.000-0400"}
+	if got, ok := d.ParsedOfficialDate(); !ok || got.Format("2006-01-02") != "2023-07-11" {
+		t.Errorf("ParsedOfficialDate() = %v, %v", got, ok)
+	}
+
+	e := TransactionEvent{Date: "not a date"}
+	if _, ok := e.ParsedDate(); ok {
+		t.Error("ParsedDate() ok for an unrecognized date")
+	}
+}
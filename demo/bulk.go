@@ -362,18 +362,11 @@ func downloadSelectedFile(ctx context.Context, client *odp.Client, productID, fi
 	fmt.Printf("Saving to: %s\n", outputPath)
 	fmt.Printf("Download URL: %s\n", fileDownloadURI)
 
-	file, err := os.Create(outputPath)
-	if err != nil {
-		fmt.Printf("Error creating file: %v\n", err)
-		return
-	}
-	defer func() { _ = file.Close() }()
-
 	startTime := time.Now()
 	var lastProgress int64
 	var lastUpdate time.Time
 
-	err = client.DownloadBulkFileWithProgress(ctx, fileDownloadURI, file,
+	err = client.DownloadBulkFileToPath(ctx, fileDownloadURI, outputPath,
 		func(bytesComplete, bytesTotal int64) {
 			now := time.Now()
 			if now.Sub(lastUpdate) > 500*time.Millisecond || bytesComplete-lastProgress > 5*1024*1024 {
@@ -401,11 +394,14 @@ func downloadSelectedFile(ctx context.Context, client *odp.Client, productID, fi
 
 	if err != nil {
 		fmt.Printf("Error downloading file: %v\n", err)
-		_ = os.Remove(outputPath)
 		return
 	}
 
-	info, _ := file.Stat()
+	info, err := os.Stat(outputPath)
+	if err != nil {
+		fmt.Printf("Error reading downloaded file: %v\n", err)
+		return
+	}
 	elapsed := time.Since(startTime)
 	avgSpeed := float64(info.Size()) / elapsed.Seconds() / 1024 / 1024
 
@@ -0,0 +1,100 @@
+package odp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/patent-dev/uspto-odp/generated"
+)
+
+// PatentDossier is everything the library can fetch about one application,
+// as written by ExportPatentDossier. Sections the API has no data for (an
+// application without term adjustment, a record without full-text XML) are
+// left nil.
+type PatentDossier struct {
+	ApplicationNumber string                        `json:"applicationNumber"`
+	Record            *generated.PatentDataResponse `json:"record"`
+	Continuity        *ContinuityResponse           `json:"continuity"`
+	Adjustment        *AdjustmentResponse           `json:"adjustment"`
+	Transactions      *TransactionsResponse         `json:"transactions"`
+	Documents         *generated.DocumentBag        `json:"documents"`
+	// Claims holds the claim texts extracted from the grant (or, before
+	// grant, the publication) XML. Omitted unless DossierOptions.Claims is
+	// set and the record has XML.
+	Claims []string `json:"claims,omitempty"`
+	// ClaimsError says why Claims is missing when the XML could not be
+	// downloaded or parsed. The rest of the dossier is still returned.
+	ClaimsError string `json:"claimsError,omitempty"`
+}
+
+// DossierOptions selects the optional, more expensive parts of a dossier.
+// A nil *DossierOptions fetches only the API sections.
+type DossierOptions struct {
+	// Claims downloads the full-text XML to fill PatentDossier.Claims.
+	Claims bool
+}
+
+// GetPatentDossier fetches the record, continuity, term adjustment,
+// transactions, and document list for a patent, plus its claims when opts
+// asks for them and full-text XML is available. patentNumber may be in any
+// format GetPatent accepts. A 404 from a sub-resource leaves that section
+// nil, and a failed XML download is recorded in ClaimsError; any other
+// error aborts.
+func (c *Client) GetPatentDossier(ctx context.Context, patentNumber string, opts *DossierOptions) (*PatentDossier, error) {
+	appNumber, err := c.resolveApplicationNumberLenient(ctx, patentNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &PatentDossier{ApplicationNumber: appNumber}
+	if d.Record, err = c.GetPatent(ctx, appNumber); err != nil {
+		return nil, fmt.Errorf("record: %w", err)
+	}
+	if d.Continuity, err = c.GetPatentContinuity(ctx, appNumber); err != nil && !isNotFoundErr(err) {
+		return nil, fmt.Errorf("continuity: %w", err)
+	}
+	if d.Adjustment, err = c.GetPatentAdjustment(ctx, appNumber); err != nil && !isNotFoundErr(err) {
+		return nil, fmt.Errorf("adjustment: %w", err)
+	}
+	if d.Transactions, err = c.GetPatentTransactions(ctx, appNumber); err != nil && !isNotFoundErr(err) {
+		return nil, fmt.Errorf("transactions: %w", err)
+	}
+	if d.Documents, err = c.GetPatentDocuments(ctx, appNumber); err != nil && !isNotFoundErr(err) {
+		return nil, fmt.Errorf("documents: %w", err)
+	}
+
+	if opts == nil || !opts.Claims {
+		return d, nil
+	}
+	// Claims come from the full-text XML, which only exists once the
+	// application has been published or granted.
+	if xmlURL, docType, urlErr := xmlURLFromPatentData(d.Record); urlErr == nil {
+		doc, err := c.DownloadXMLWithType(ctx, xmlURL, docType)
+		switch {
+		case ctx.Err() != nil:
+			return nil, ctx.Err()
+		case err != nil:
+			d.ClaimsError = err.Error()
+		default:
+			d.Claims = doc.GetClaims().ExtractAllClaimsText()
+		}
+	}
+	return d, nil
+}
+
+// ExportPatentDossier writes the dossier for a patent (see GetPatentDossier)
+// to w as a single indented JSON object.
+func (c *Client) ExportPatentDossier(ctx context.Context, patentNumber string, w io.Writer, opts *DossierOptions) error {
+	d, err := c.GetPatentDossier(ctx, patentNumber, opts)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(d); err != nil {
+		return fmt.Errorf("writing dossier: %w", err)
+	}
+	return nil
+}
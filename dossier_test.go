@@ -0,0 +1,144 @@
+package odp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newDossierServer serves every section of a dossier for application
+// 17248024. The adjustment endpoint returns 404 to exercise the
+// missing-section path, and so does the grant XML unless xmlOK is set.
+func newDossierServer(t *testing.T, xmlOK bool) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/patent/applications/17248024":
+			fmt.Fprintf(w, `{"count":1,"patentFileWrapperDataBag":[{
+				"applicationNumberText":"17248024",
+				"applicationMetaData":{"patentNumber":"11646472","inventionTitle":"Electrode protection"},
+				"grantDocumentMetaData":{"fileLocationURI":"%s/files/17248024.xml"}}]}`, server.URL)
+		case "/api/v1/patent/applications/17248024/continuity":
+			_, _ = w.Write([]byte(`{"count":1,"patentFileWrapperDataBag":[{
+				"applicationNumberText":"17248024",
+				"parentContinuityBag":[{"parentApplicationNumberText":"16000001","claimParentageTypeCode":"CON"}]}]}`))
+		case "/api/v1/patent/applications/17248024/adjustment":
+			w.Header().Del("Content-Type")
+			w.WriteHeader(http.StatusNotFound)
+		case "/api/v1/patent/applications/17248024/transactions":
+			_, _ = w.Write([]byte(`{"count":1,"patentFileWrapperDataBag":[{
+				"applicationNumberText":"17248024",
+				"eventDataBag":[{"eventCode":"EML_NTR","eventDate":"2023-10-03","eventDescriptionText":"Email Notification"}]}]}`))
+		case "/api/v1/patent/applications/17248024/documents":
+			_, _ = w.Write([]byte(`{"documentBag":[{"documentCode":"EGRANT.PDF","documentIdentifier":"LGXYZ"}]}`))
+		case "/files/17248024.xml":
+			if !xmlOK {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write([]byte(sampleGrantXML))
+		default:
+			w.Header().Del("Content-Type")
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+func newDossierClient(t *testing.T, baseURL string) *Client {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.BaseURL = baseURL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func TestExportPatentDossier(t *testing.T) {
+	server := newDossierServer(t, true)
+	defer server.Close()
+	client := newDossierClient(t, server.URL)
+
+	var buf bytes.Buffer
+	if err := client.ExportPatentDossier(context.Background(), "17248024", &buf, &DossierOptions{Claims: true}); err != nil {
+		t.Fatalf("ExportPatentDossier: %v", err)
+	}
+
+	var out map[string]json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("output is not a JSON object: %v", err)
+	}
+	for _, section := range []string{"applicationNumber", "record", "continuity", "adjustment", "transactions", "documents", "claims"} {
+		if _, ok := out[section]; !ok {
+			t.Errorf("dossier missing %q section", section)
+		}
+	}
+	if got := string(out["applicationNumber"]); got != `"17248024"` {
+		t.Errorf("applicationNumber = %s, want \"17248024\"", got)
+	}
+	if got := string(out["adjustment"]); got != "null" {
+		t.Errorf("adjustment = %s, want null for a 404", got)
+	}
+
+	var d PatentDossier
+	if err := json.Unmarshal(buf.Bytes(), &d); err != nil {
+		t.Fatalf("decode dossier: %v", err)
+	}
+	if d.Continuity == nil || len(d.Continuity.Parents) != 1 || d.Continuity.Parents[0].ApplicationNumber != "16000001" {
+		t.Errorf("continuity = %+v, want parent 16000001", d.Continuity)
+	}
+	if d.Transactions == nil || len(d.Transactions.Events) != 1 {
+		t.Errorf("transactions = %+v, want one event", d.Transactions)
+	}
+	if d.Documents == nil || d.Documents.DocumentBag == nil || len(*d.Documents.DocumentBag) != 1 {
+		t.Errorf("documents = %+v, want one document", d.Documents)
+	}
+	if len(d.Claims) != 3 {
+		t.Errorf("claims = %d, want 3 from the sample grant", len(d.Claims))
+	}
+}
+
+func TestGetPatentDossier_RecordNotFound(t *testing.T) {
+	server := newDossierServer(t, true)
+	defer server.Close()
+	client := newDossierClient(t, server.URL)
+
+	if _, err := client.GetPatentDossier(context.Background(), "17999998", nil); !isNotFoundErr(err) {
+		t.Errorf("GetPatentDossier(unknown) error = %v, want 404", err)
+	}
+}
+
+func TestGetPatentDossier_Claims(t *testing.T) {
+	server := newDossierServer(t, false)
+	defer server.Close()
+	client := newDossierClient(t, server.URL)
+
+	// Without options no XML is fetched.
+	d, err := client.GetPatentDossier(context.Background(), "17248024", nil)
+	if err != nil {
+		t.Fatalf("GetPatentDossier: %v", err)
+	}
+	if d.Claims != nil || d.ClaimsError != "" {
+		t.Errorf("claims = %v, %q; want none without DossierOptions.Claims", d.Claims, d.ClaimsError)
+	}
+
+	// A failed XML download is recorded, not fatal.
+	d, err = client.GetPatentDossier(context.Background(), "17248024", &DossierOptions{Claims: true})
+	if err != nil {
+		t.Fatalf("GetPatentDossier with a missing XML: %v", err)
+	}
+	if d.Record == nil || d.Claims != nil || !strings.Contains(d.ClaimsError, "404") {
+		t.Errorf("dossier = record %v, claims %v, error %q; want the record and a 404 ClaimsError", d.Record != nil, d.Claims, d.ClaimsError)
+	}
+}
@@ -3,8 +3,10 @@ package odp
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"strconv"
@@ -23,12 +25,55 @@ type APIError struct {
 	// Empty is set when the server returned a success status with no body. USPTO
 	// services (notably TSDR) do this when degraded; it is treated as transient.
 	Empty bool
+	// Truncated is set when a success response body did not match its declared
+	// Content-Length (the connection dropped mid-body). Treated as transient.
+	Truncated bool
+	// RequestIdentifier is the requestIdentifier the API includes in its JSON
+	// error bodies; quote it when filing a USPTO support case. Empty when the
+	// body carried none.
+	RequestIdentifier string
+	// Endpoint is the method and path of the failed request, e.g.
+	// "GET /api/v1/patent/applications/17248024".
+	Endpoint string
 }
 
 func (e *APIError) Error() string {
 	return e.Message
 }
 
+// ErrNotFound is matched by errors.Is for an *APIError with HTTP 404, e.g.
+// an application, petition or document that does not exist:
+//
+//	if errors.Is(err, odp.ErrNotFound) {
+//		continue
+//	}
+var ErrNotFound = errors.New("resource not found")
+
+// ErrUnauthorized is matched by errors.Is for an *APIError with HTTP 401 or
+// 403: a missing, invalid or expired API key, or one without access to the
+// API called. Retrying will not help.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// ErrRateLimited is matched by errors.Is for an *APIError with HTTP 429,
+// returned once the client's own retries are used up or the server asked
+// for a longer wait than Config.MaxRetryAfter; RetryAfter holds the wait
+// it asked for.
+var ErrRateLimited = errors.New("rate limited")
+
+// Is lets errors.Is match an *APIError against the sentinel for its status
+// code.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
 // Detail returns the error message with the server response body, if available.
 func (e *APIError) Detail() string {
 	if e.Body != "" {
@@ -37,12 +82,26 @@ func (e *APIError) Detail() string {
 	return e.Message
 }
 
-// IsRetryable returns true for transient conditions: HTTP 429, 5xx, or an empty
-// body on a success status (a degraded-service symptom that often clears on a
-// retry). The Retry-After cap is a *client* policy, enforced by retryableRequest,
-// not by the error itself.
+// IsRetryable returns true for transient conditions: HTTP 429, 500, 502, 503
+// and 504, or an empty or truncated body on a success status (degraded-service
+// and dropped-connection symptoms that often clear on a retry). Other 4xx and
+// 5xx statuses (404, 400, 501, ...) will not change on a retry and fail fast.
+// The Retry-After cap is a *client* policy, enforced by retryableRequest, not
+// by the error itself.
 func (e *APIError) IsRetryable() bool {
-	return e.Empty || e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+	if e.Empty || e.Truncated {
+		return true
+	}
+	switch e.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
 }
 
 func isRetryableError(err error) bool {
@@ -59,6 +118,11 @@ func isRetryableError(err error) bool {
 	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 		return false
 	}
+	// The connection closed before the declared Content-Length was read: the
+	// transport surfaces this as io.ErrUnexpectedEOF while reading the body.
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
 	// Only retry on network-level transient errors (timeouts, connection resets)
 	var netErr net.Error
 	if errors.As(err, &netErr) {
@@ -95,10 +159,13 @@ func parseRetryAfter(headers http.Header) time.Duration {
 }
 
 // checkResponseStatus returns an APIError for non-2xx responses, including
-// the response body for debugging. If headers is non-nil, the Retry-After
-// value (if present) is parsed onto the APIError.
-func checkResponseStatus(statusCode int, body []byte, headers http.Header) error {
+// the response body and its requestIdentifier for debugging. resp may be nil;
+// when set, its Retry-After header and the request's method and path are
+// recorded on the APIError, and a success body's requestIdentifier goes to
+// any RequestIDs on the request's context.
+func checkResponseStatus(statusCode int, body []byte, resp *http.Response) error {
 	if statusCode >= 200 && statusCode < 300 {
+		recordRequestID(resp, body)
 		return nil
 	}
 	apiErr := &APIError{
@@ -109,11 +176,54 @@ func checkResponseStatus(statusCode int, body []byte, headers http.Header) error
 		// 4 KiB keeps debug payloads (USPTO often echoes the request body
 		// in 4xx responses) without exposing arbitrarily large blobs.
 		apiErr.Body = truncatePreview(string(body), 4096)
+		var id struct {
+			RequestIdentifier string `json:"requestIdentifier"`
+		}
+		if json.Unmarshal(body, &id) == nil {
+			apiErr.RequestIdentifier = id.RequestIdentifier
+		}
+		// A bare "status 500" says nothing; the server's own explanation,
+		// when its body has one, goes into the message so it survives
+		// retries and reaches logs that only print Error().
+		if statusCode >= 500 {
+			if msg := serverErrorMessage(body); msg != "" {
+				apiErr.Message += ": " + msg
+			}
+		}
 	}
-	apiErr.RetryAfter = parseRetryAfter(headers)
+	if resp != nil && resp.Request != nil && resp.Request.URL != nil {
+		apiErr.Endpoint = resp.Request.Method + " " + resp.Request.URL.Path
+	}
+	apiErr.RetryAfter = parseRetryAfter(headerOf(resp))
 	return apiErr
 }
 
+// serverErrorMessage extracts a short explanation from a 5xx body: the
+// detailedMessage, message or errorDetails field of a JSON error, or the
+// text of a plain-text body. HTML error pages from gateways yield "".
+func serverErrorMessage(body []byte) string {
+	const maxLen = 200
+	var e struct {
+		DetailedMessage string `json:"detailedMessage"`
+		Message         string `json:"message"`
+		ErrorDetails    string `json:"errorDetails"`
+		Error           string `json:"error"`
+	}
+	if json.Unmarshal(body, &e) == nil {
+		for _, m := range []string{e.DetailedMessage, e.Message, e.ErrorDetails, e.Error} {
+			if m = normalizeSpace(m); m != "" {
+				return truncatePreview(m, maxLen)
+			}
+		}
+		return ""
+	}
+	text := normalizeSpace(string(body))
+	if text == "" || strings.HasPrefix(text, "<") || strings.HasPrefix(text, "{") || strings.HasPrefix(text, "[") {
+		return ""
+	}
+	return truncatePreview(text, maxLen)
+}
+
 // checkEmptyBody reports a clear, retryable error when a success response carries
 // no body. USPTO services (notably TSDR) occasionally return an empty 200/204 when
 // degraded; without this, callers fail later with an opaque "unexpected end of
@@ -129,3 +239,20 @@ func checkEmptyBody(statusCode int, body []byte) error {
 		Message:    fmt.Sprintf("USPTO returned an empty response body with HTTP %d", statusCode),
 	}
 }
+
+// checkBodyLength reports a retryable error when a success response body does
+// not match the declared Content-Length. The download endpoints return whole
+// exports in one body; without this a short read that the transport did not
+// flag would hand the caller silently truncated data. A response without a
+// declared length (chunked or transparently decompressed) is not checked.
+func checkBodyLength(resp *http.Response, body []byte) error {
+	if resp == nil || resp.ContentLength < 0 || int64(len(body)) == resp.ContentLength {
+		return nil
+	}
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Truncated:  true,
+		Message: fmt.Sprintf("response body is %d bytes but Content-Length declared %d",
+			len(body), resp.ContentLength),
+	}
+}
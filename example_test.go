@@ -2,6 +2,7 @@ package odp_test
 
 import (
 	"fmt"
+	"time"
 
 	odp "github.com/patent-dev/uspto-odp"
 )
@@ -20,6 +21,19 @@ func ExampleNewClient() {
 	// Output: true
 }
 
+func ExampleNewClientWithOptions() {
+	// Options start from DefaultConfig, so only the differences are named.
+	client, err := odp.NewClientWithOptions(
+		odp.WithAPIKey("your-api-key"),
+		odp.WithTimeout(time.Minute),
+	)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(client != nil)
+	// Output: true
+}
+
 func ExampleNormalizePatentNumber() {
 	// Patent numbers are normalized offline; resolving a grant or publication
 	// number to its application number needs a client call.
@@ -0,0 +1,119 @@
+package odp
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// PatentRecord is a PatentFileWrapper that also keeps the fields the
+// generated types do not model. When USPTO adds a field to the API, it is
+// dropped by the generated structs but lands in Extra until the types catch
+// up:
+//
+//	var rec odp.PatentRecord
+//	err := json.Unmarshal(raw, &rec)
+//	if v, ok := rec.Extra["applicationMetaData.newField"]; ok { ... }
+type PatentRecord struct {
+	PatentFileWrapper
+	// Extra maps the dotted path of each unmodeled field to its raw value.
+	// Nested objects of modeled fields are searched too; arrays are not.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the modeled fields and collects the rest into Extra.
+func (r *PatentRecord) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &r.PatentFileWrapper); err != nil {
+		return err
+	}
+	extra, err := unknownFields(data, reflect.TypeOf(r.PatentFileWrapper))
+	if err != nil {
+		return err
+	}
+	r.Extra = extra
+	return nil
+}
+
+// unknownFields returns the fields of the JSON object data that have no
+// counterpart in struct type t, keyed by dotted path. A nil map means every
+// field is modeled.
+func unknownFields(data []byte, t reflect.Type) (map[string]json.RawMessage, error) {
+	extra := map[string]json.RawMessage{}
+	if err := collectUnknownFields(data, t, "", extra); err != nil {
+		return nil, err
+	}
+	if len(extra) == 0 {
+		return nil, nil
+	}
+	return extra, nil
+}
+
+func collectUnknownFields(data []byte, t reflect.Type, prefix string, extra map[string]json.RawMessage) error {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil || obj == nil {
+		// Not an object (or null): nothing to compare field by field.
+		return nil
+	}
+	fields := jsonFields(t)
+	for key, raw := range obj {
+		ft, ok := fields[key]
+		if !ok {
+			// encoding/json matches keys case-insensitively as a fallback.
+			for name, typ := range fields {
+				if strings.EqualFold(name, key) {
+					ft, ok = typ, true
+					break
+				}
+			}
+		}
+		if !ok {
+			extra[prefix+key] = raw
+			continue
+		}
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct {
+			if err := collectUnknownFields(raw, ft, prefix+key+".", extra); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// jsonFields maps the JSON names of t's fields, including those promoted
+// from embedded structs, to their types.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	fields := map[string]reflect.Type{}
+	if t.Kind() != reflect.Struct {
+		return fields
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			for k, v := range jsonFields(f.Type) {
+				if _, shadowed := fields[k]; !shadowed {
+					fields[k] = v
+				}
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
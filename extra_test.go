@@ -0,0 +1,80 @@
+package odp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// sampleRecordWithUnknownFields is a meta-data record carrying fields the
+// generated types do not model, at the record level and inside
+// applicationMetaData.
+const sampleRecordWithUnknownFields = `{"count":1,"patentFileWrapperDataBag":[{
+	"applicationNumberText":"17248024",
+	"aiAssistedIndicator":true,
+	"applicationMetaData":{
+		"inventionTitle":"Electrode assembly",
+		"filingDate":"2021-01-15",
+		"examinerEmailText":"examiner@uspto.gov"
+	},
+	"eventDataBag":[{"eventCode":"CTNF","eventNewField":"ignored in arrays"}]
+}]}`
+
+func TestPatentRecord_UnmarshalCapturesUnknownFields(t *testing.T) {
+	var resp struct {
+		PatentFileWrapperDataBag []PatentRecord `json:"patentFileWrapperDataBag"`
+	}
+	if err := json.Unmarshal([]byte(sampleRecordWithUnknownFields), &resp); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	rec := resp.PatentFileWrapperDataBag[0]
+	if derefStr(rec.ApplicationNumberText) != "17248024" || derefStr(rec.ApplicationMetaData.InventionTitle) != "Electrode assembly" {
+		t.Errorf("modeled fields not decoded: %+v", rec.PatentFileWrapper)
+	}
+	want := map[string]string{
+		"aiAssistedIndicator":                   `true`,
+		"applicationMetaData.examinerEmailText": `"examiner@uspto.gov"`,
+	}
+	if len(rec.Extra) != len(want) {
+		t.Errorf("Extra = %v, want %d entries", rec.Extra, len(want))
+	}
+	for k, v := range want {
+		if got := string(rec.Extra[k]); got != v {
+			t.Errorf("Extra[%q] = %s, want %s", k, got, v)
+		}
+	}
+
+	var known PatentRecord
+	if err := json.Unmarshal([]byte(`{"applicationNumberText":"1"}`), &known); err != nil || known.Extra != nil {
+		t.Errorf("fully modeled record: Extra = %v, err = %v; want nil", known.Extra, err)
+	}
+}
+
+func TestGetPatentMetaData_CaptureUnknownFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(sampleRecordWithUnknownFields))
+	}))
+	defer server.Close()
+
+	for _, capture := range []bool{false, true} {
+		cfg := DefaultConfig()
+		cfg.BaseURL = server.URL
+		cfg.APIKey = "test"
+		cfg.CaptureUnknownFields = capture
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		meta, err := client.GetPatentMetaData(context.Background(), "17248024")
+		if err != nil {
+			t.Fatalf("GetPatentMetaData: %v", err)
+		}
+		_, got := meta.Extra["applicationMetaData.examinerEmailText"]
+		if got != capture {
+			t.Errorf("CaptureUnknownFields=%v: Extra = %v", capture, meta.Extra)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package odp
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// FamilyNode is one application in a patent family.
+type FamilyNode struct {
+	ApplicationNumber string
+	PatentNumber      string
+	FilingDate        string
+	Status            string
+	// Depth is the number of continuity hops from the application the
+	// family was built from (0 for the root).
+	Depth int
+}
+
+// FamilyEdge is a continuity relationship between two applications.
+type FamilyEdge struct {
+	Parent           string // application number
+	Child            string // application number
+	RelationshipType string
+}
+
+// FamilyTree is the continuity graph around one application, as returned by
+// GetPatentFamily. Nodes are in breadth-first order starting with the root;
+// each application and each parent/child pair appears once.
+type FamilyTree struct {
+	Root  string
+	Nodes []FamilyNode
+	Edges []FamilyEdge
+}
+
+// Contains reports whether applicationNumber is a node of the family.
+func (f *FamilyTree) Contains(applicationNumber string) bool {
+	for _, n := range f.Nodes {
+		if n.ApplicationNumber == applicationNumber {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPatentFamily builds the continuity family of a patent by following
+// parent and child applications from GetPatentContinuity, breadth-first, up to
+// maxDepth hops from the starting application. patentNumber may be in any
+// format GetPatent accepts. Relatives the API has no continuity record for
+// are kept as leaves.
+func (c *Client) GetPatentFamily(ctx context.Context, patentNumber string, maxDepth int) (*FamilyTree, error) {
+	if maxDepth < 1 {
+		return nil, fmt.Errorf("family depth must be >= 1, got %d", maxDepth)
+	}
+	root, err := c.resolveApplicationNumberLenient(ctx, patentNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &FamilyTree{Root: root, Nodes: []FamilyNode{{ApplicationNumber: root}}}
+	index := map[string]int{root: 0}
+	edges := map[[2]string]bool{}
+
+	addNode := func(n FamilyNode) {
+		if n.ApplicationNumber == "" {
+			return
+		}
+		if i, ok := index[n.ApplicationNumber]; ok {
+			// Fill in details a shallower sighting lacked.
+			existing := &tree.Nodes[i]
+			if existing.PatentNumber == "" {
+				existing.PatentNumber = n.PatentNumber
+			}
+			if existing.FilingDate == "" {
+				existing.FilingDate = n.FilingDate
+			}
+			if existing.Status == "" {
+				existing.Status = n.Status
+			}
+			return
+		}
+		index[n.ApplicationNumber] = len(tree.Nodes)
+		tree.Nodes = append(tree.Nodes, n)
+	}
+	addEdge := func(parent, child, relationship string) {
+		if parent == "" || child == "" || edges[[2]string{parent, child}] {
+			return
+		}
+		edges[[2]string{parent, child}] = true
+		tree.Edges = append(tree.Edges, FamilyEdge{Parent: parent, Child: child, RelationshipType: relationship})
+	}
+
+	// tree.Nodes doubles as the BFS queue: nodes are appended in visit order
+	// and their Depth never changes once added.
+	for i := 0; i < len(tree.Nodes); i++ {
+		node := tree.Nodes[i]
+		if node.Depth >= maxDepth {
+			continue
+		}
+		cont, err := c.GetPatentContinuity(ctx, node.ApplicationNumber)
+		if err != nil {
+			if i > 0 && isNotFoundErr(err) {
+				continue
+			}
+			return nil, fmt.Errorf("continuity for %s: %w", node.ApplicationNumber, err)
+		}
+		for _, p := range cont.Parents {
+			addNode(FamilyNode{
+				ApplicationNumber: p.ApplicationNumber,
+				PatentNumber:      p.PatentNumber,
+				FilingDate:        p.FilingDate,
+				Status:            p.Status,
+				Depth:             node.Depth + 1,
+			})
+			addEdge(p.ApplicationNumber, node.ApplicationNumber, p.RelationshipType)
+		}
+		for _, ch := range cont.Children {
+			addNode(FamilyNode{
+				ApplicationNumber: ch.ApplicationNumber,
+				PatentNumber:      ch.PatentNumber,
+				FilingDate:        ch.FilingDate,
+				Status:            ch.Status,
+				Depth:             node.Depth + 1,
+			})
+			addEdge(node.ApplicationNumber, ch.ApplicationNumber, ch.RelationshipType)
+		}
+	}
+	return tree, nil
+}
+
+// relatedFamilyDepth bounds the family walk in AreRelated. Three hops reach
+// grandparents, grandchildren, and siblings through a shared parent.
+const relatedFamilyDepth = 3
+
+// AreRelated reports whether two patents belong to the same continuity
+// family, i.e. one is reachable from the other within a few parent/child
+// hops. Both numbers may be in any format GetPatent accepts.
+func (c *Client) AreRelated(ctx context.Context, a, b string) (bool, error) {
+	appA, err := c.resolveApplicationNumberLenient(ctx, a)
+	if err != nil {
+		return false, err
+	}
+	appB, err := c.resolveApplicationNumberLenient(ctx, b)
+	if err != nil {
+		return false, err
+	}
+	if appA == appB {
+		return true, nil
+	}
+	family, err := c.GetPatentFamily(ctx, appA, relatedFamilyDepth)
+	if isNotFoundErr(err) {
+		// No continuity record: a has no family for b to belong to.
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return family.Contains(appB), nil
+}
+
+// GetPatentDocumentsIncludingParents lists the file wrapper documents of an
+// application together with those of its continuity parents, up to maxDepth
+// parent generations (0 lists the application alone). The application's own
+// documents come first, then each ancestor's in breadth-first order; every
+// document is tagged with the application it belongs to. A parent the API
+// has no continuity or document record for contributes nothing.
+func (c *Client) GetPatentDocumentsIncludingParents(ctx context.Context, applicationNumber string, maxDepth int) ([]FileWrapperDocument, error) {
+	if maxDepth < 0 {
+		return nil, fmt.Errorf("parent depth must be >= 0, got %d", maxDepth)
+	}
+	type queued struct {
+		app   string
+		depth int
+	}
+	queue := []queued{{app: applicationNumber}}
+	seen := map[string]bool{applicationNumber: true}
+	var docs []FileWrapperDocument
+
+	for i := 0; i < len(queue); i++ {
+		q := queue[i]
+		bag, err := c.GetPatentDocuments(ctx, q.app)
+		if err != nil {
+			if i > 0 && isNotFoundErr(err) {
+				continue
+			}
+			return nil, fmt.Errorf("documents for %s: %w", q.app, err)
+		}
+		docs = append(docs, documentsFromBag(q.app, bag)...)
+
+		if q.depth >= maxDepth {
+			continue
+		}
+		cont, err := c.GetPatentContinuity(ctx, q.app)
+		if err != nil {
+			if isNotFoundErr(err) {
+				continue
+			}
+			return nil, fmt.Errorf("continuity for %s: %w", q.app, err)
+		}
+		for _, p := range cont.Parents {
+			if p.ApplicationNumber == "" || seen[p.ApplicationNumber] {
+				continue
+			}
+			seen[p.ApplicationNumber] = true
+			queue = append(queue, queued{app: p.ApplicationNumber, depth: q.depth + 1})
+		}
+	}
+	return docs, nil
+}
+
+// EarliestUSFilingDate returns the earliest US non-provisional filing date
+// in a patent's chain of continuity parents - the date the 20-year term of
+// 35 U.S.C. 154(a)(2) runs from - together with the application filed on it.
+// The patent's own application counts, so one without parents returns its
+// own filing date. Provisional parents are skipped and not followed:
+// they do not start the term. Foreign priority is not part of continuity
+// data and never considered. patentNumber may be in any format GetPatent
+// accepts.
+func (c *Client) EarliestUSFilingDate(ctx context.Context, patentNumber string) (time.Time, string, error) {
+	root, err := c.resolveApplicationNumberLenient(ctx, patentNumber)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	meta, err := c.GetPatentMetaData(ctx, root)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("metadata for %s: %w", root, err)
+	}
+	earliest, earliestApp := time.Time{}, ""
+	consider := func(app, filed string) {
+		if d, ok := parseDate(filed); ok && (earliestApp == "" || d.Before(earliest)) {
+			earliest, earliestApp = d, app
+		}
+	}
+	consider(root, meta.FilingDate)
+
+	queue := []string{root}
+	seen := map[string]bool{root: true}
+	for i := 0; i < len(queue); i++ {
+		cont, err := c.GetPatentContinuity(ctx, queue[i])
+		if err != nil {
+			if isNotFoundErr(err) {
+				continue
+			}
+			return time.Time{}, "", fmt.Errorf("continuity for %s: %w", queue[i], err)
+		}
+		for _, p := range cont.Parents {
+			if p.ApplicationNumber == "" || seen[p.ApplicationNumber] || isProvisionalParent(p) {
+				continue
+			}
+			seen[p.ApplicationNumber] = true
+			consider(p.ApplicationNumber, p.FilingDate)
+			queue = append(queue, p.ApplicationNumber)
+		}
+	}
+	if earliestApp == "" {
+		return time.Time{}, "", fmt.Errorf("no filing date found for %s or its parents", root)
+	}
+	return earliest, earliestApp, nil
+}
+
+// isProvisionalParent reports whether a continuity parent is a provisional
+// application: by relationship (claimParentageTypeCode PRO), or by its
+// 60-63 series number when the relationship is missing.
+func isProvisionalParent(p ContinuityParent) bool {
+	if p.RelationshipType == "Provisional" {
+		return true
+	}
+	return applicationCategoryOf(p.ApplicationNumber) == ApplicationCategoryProvisional
+}
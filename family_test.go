@@ -0,0 +1,216 @@
+package odp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newFamilyServer serves continuity for a small family: 16000001 is the
+// parent of 17000002 (CON) and 17000003 (DIV). 18000004 is unrelated and has
+// an empty continuity record. 17000002 and 16000001 each list one file
+// wrapper document. 18000010 (filed 2019-02-01) continues 15000011 (filed
+// 2016-05-01); both claim an earlier provisional. Anything else is 404.
+func newFamilyServer(t *testing.T, calls map[string]int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/patent/applications/16000001/continuity":
+			_, _ = w.Write([]byte(`{"count":1,"patentFileWrapperDataBag":[{
+				"applicationNumberText":"16000001",
+				"childContinuityBag":[
+					{"childApplicationNumberText":"17000002","childPatentNumber":"11000002","claimParentageTypeCode":"CON"},
+					{"childApplicationNumberText":"17000003","claimParentageTypeCode":"DIV"}]}]}`))
+		case "/api/v1/patent/applications/17000002/continuity":
+			_, _ = w.Write([]byte(`{"count":1,"patentFileWrapperDataBag":[{
+				"applicationNumberText":"17000002",
+				"parentContinuityBag":[{"parentApplicationNumberText":"16000001","parentPatentNumber":"10000001","claimParentageTypeCode":"CON"}]}]}`))
+		case "/api/v1/patent/applications/17000003/continuity":
+			_, _ = w.Write([]byte(`{"count":1,"patentFileWrapperDataBag":[{
+				"applicationNumberText":"17000003",
+				"parentContinuityBag":[{"parentApplicationNumberText":"16000001","claimParentageTypeCode":"DIV"}]}]}`))
+		case "/api/v1/patent/applications/17000002/documents":
+			_, _ = w.Write([]byte(`{"count":1,"documentBag":[{"applicationNumberText":"17000002",
+				"documentIdentifier":"DOC2","documentCode":"CTNF","directionCategory":"OUTGOING",
+				"downloadOptionBag":[{"mimeTypeIdentifier":"PDF","downloadUrl":"https://api.uspto.gov/api/v1/download/applications/17000002/DOC2.pdf","pageTotalQuantity":9}]}]}`))
+		case "/api/v1/patent/applications/16000001/documents":
+			_, _ = w.Write([]byte(`{"count":1,"documentBag":[{"applicationNumberText":"16000001",
+				"documentIdentifier":"DOC1","documentCode":"NOA","directionCategory":"OUTGOING"}]}`))
+		case "/api/v1/patent/applications/18000010/meta-data":
+			_, _ = w.Write([]byte(`{"count":1,"patentFileWrapperDataBag":[{"applicationNumberText":"18000010",
+				"applicationMetaData":{"filingDate":"2019-02-01"}}]}`))
+		case "/api/v1/patent/applications/18000010/continuity":
+			_, _ = w.Write([]byte(`{"count":1,"patentFileWrapperDataBag":[{
+				"applicationNumberText":"18000010",
+				"parentContinuityBag":[
+					{"parentApplicationNumberText":"15000011","parentApplicationFilingDate":"2016-05-01","claimParentageTypeCode":"CON"},
+					{"parentApplicationNumberText":"62000012","parentApplicationFilingDate":"2015-05-01","claimParentageTypeCode":"PRO"}]}]}`))
+		case "/api/v1/patent/applications/15000011/continuity":
+			_, _ = w.Write([]byte(`{"count":1,"patentFileWrapperDataBag":[{
+				"applicationNumberText":"15000011",
+				"parentContinuityBag":[{"parentApplicationNumberText":"62000013","parentApplicationFilingDate":"2015-01-01"}]}]}`))
+		case "/api/v1/patent/applications/18000004/continuity":
+			_, _ = w.Write([]byte(`{"count":1,"patentFileWrapperDataBag":[{"applicationNumberText":"18000004"}]}`))
+		default:
+			w.Header().Del("Content-Type")
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestGetPatentFamily(t *testing.T) {
+	calls := map[string]int{}
+	server := newFamilyServer(t, calls)
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	tree, err := client.GetPatentFamily(context.Background(), "17000002", 2)
+	if err != nil {
+		t.Fatalf("GetPatentFamily: %v", err)
+	}
+	if tree.Root != "17000002" {
+		t.Errorf("Root = %q, want 17000002", tree.Root)
+	}
+	wantDepth := map[string]int{"17000002": 0, "16000001": 1, "17000003": 2}
+	if len(tree.Nodes) != len(wantDepth) {
+		t.Fatalf("Nodes = %+v, want %d nodes", tree.Nodes, len(wantDepth))
+	}
+	for _, n := range tree.Nodes {
+		if d, ok := wantDepth[n.ApplicationNumber]; !ok || d != n.Depth {
+			t.Errorf("node %s at depth %d, want depth %d", n.ApplicationNumber, n.Depth, d)
+		}
+	}
+	if tree.Nodes[0].PatentNumber != "11000002" {
+		t.Errorf("root PatentNumber = %q, want 11000002 filled from the parent's child entry", tree.Nodes[0].PatentNumber)
+	}
+	// 16000001->17000002 is reported from both sides but must appear once.
+	if len(tree.Edges) != 2 {
+		t.Errorf("Edges = %+v, want 2 deduplicated edges", tree.Edges)
+	}
+	for _, e := range tree.Edges {
+		if e.Parent != "16000001" {
+			t.Errorf("edge %+v, want parent 16000001", e)
+		}
+	}
+	// Depth cap: the depth-2 sibling is not expanded.
+	if calls["/api/v1/patent/applications/17000003/continuity"] != 0 {
+		t.Error("node at maxDepth was expanded")
+	}
+
+	if _, err := client.GetPatentFamily(context.Background(), "17000002", 0); err == nil {
+		t.Error("GetPatentFamily(depth 0) succeeded, want error")
+	}
+}
+
+func TestAreRelated(t *testing.T) {
+	server := newFamilyServer(t, map[string]int{})
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"16000001", "17000002", true},  // parent/child
+		{"17000002", "17000003", true},  // siblings via a shared parent
+		{"17000002", "17000002", true},  // same application
+		{"17000002", "18000004", false}, // unrelated
+		{"18000004", "16000001", false}, // empty continuity record
+		{"19000005", "16000001", false}, // no continuity record at all
+	}
+	for _, tt := range tests {
+		got, err := client.AreRelated(context.Background(), tt.a, tt.b)
+		if err != nil {
+			t.Errorf("AreRelated(%s, %s): %v", tt.a, tt.b, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("AreRelated(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestGetPatentDocumentsIncludingParents(t *testing.T) {
+	calls := map[string]int{}
+	server := newFamilyServer(t, calls)
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	docs, err := client.GetPatentDocumentsIncludingParents(context.Background(), "17000002", 2)
+	if err != nil {
+		t.Fatalf("GetPatentDocumentsIncludingParents: %v", err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("got %d documents, want 2: %+v", len(docs), docs)
+	}
+	if docs[0].ApplicationNumber != "17000002" || docs[0].Code != "CTNF" {
+		t.Errorf("docs[0] = %+v, want the child's CTNF", docs[0])
+	}
+	if len(docs[0].Downloads) != 1 || docs[0].Downloads[0].Pages != 9 {
+		t.Errorf("docs[0].Downloads = %+v", docs[0].Downloads)
+	}
+	if docs[1].ApplicationNumber != "16000001" || docs[1].Code != "NOA" {
+		t.Errorf("docs[1] = %+v, want the parent's NOA", docs[1])
+	}
+	// Parents only: the sibling 17000003 is never visited.
+	if calls["/api/v1/patent/applications/17000003/documents"] != 0 {
+		t.Error("sibling documents were fetched")
+	}
+
+	docs, err = client.GetPatentDocumentsIncludingParents(context.Background(), "17000002", 0)
+	if err != nil || len(docs) != 1 {
+		t.Errorf("maxDepth 0 = %d docs, %v; want the application's own document", len(docs), err)
+	}
+}
+
+func TestEarliestUSFilingDate(t *testing.T) {
+	server := newFamilyServer(t, map[string]int{})
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	// The CON parent predates the child; the provisionals, earlier still,
+	// do not count.
+	date, app, err := client.EarliestUSFilingDate(context.Background(), "18000010")
+	if err != nil {
+		t.Fatalf("EarliestUSFilingDate: %v", err)
+	}
+	if want := time.Date(2016, 5, 1, 0, 0, 0, 0, time.UTC); !date.Equal(want) || app != "15000011" {
+		t.Errorf("EarliestUSFilingDate = %s, %s; want 2016-05-01, 15000011", date.Format(time.DateOnly), app)
+	}
+
+	if _, _, err := client.EarliestUSFilingDate(context.Background(), "19000005"); err == nil {
+		t.Error("expected an error for an application without metadata")
+	}
+}
@@ -0,0 +1,41 @@
+package odp
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// maxInspectedBody is how much of a response body Config.ResponseInspector
+// gets to see.
+const maxInspectedBody = 64 << 10
+
+// inspectingTransport shows every response to Config.ResponseInspector
+// before the client reads it.
+type inspectingTransport struct {
+	base    http.RoundTripper
+	inspect func(*http.Response)
+}
+
+func (t *inspectingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	// Peek buffers the start of the body; the client then reads through the
+	// same buffer, so it sees every byte. A read error met while peeking is
+	// returned again when the client reaches it.
+	br := bufio.NewReaderSize(resp.Body, maxInspectedBody)
+	head, _ := br.Peek(maxInspectedBody)
+
+	inspected := *resp
+	inspected.Body = io.NopCloser(bytes.NewReader(bytes.Clone(head)))
+	t.inspect(&inspected)
+
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{br, resp.Body}
+	return resp, nil
+}
@@ -0,0 +1,69 @@
+package odp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestResponseInspector(t *testing.T) {
+	// The title pushes the body past what the inspector is shown, to check
+	// the client still reads all of it.
+	title := strings.Repeat("x", maxInspectedBody+1000)
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("X-Request-Id", "req-"+strconv.Itoa(attempts))
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("try again"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		writeWrapperBag(w, "17248024", title)
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var seen []string
+	inspector := func(resp *http.Response) {
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, resp.Header.Get("X-Request-Id")+" "+resp.Status[:3]+" "+string(body[:min(9, len(body))]))
+		if len(body) > maxInspectedBody {
+			t.Errorf("inspector saw %d body bytes, want at most %d", len(body), maxInspectedBody)
+		}
+	}
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	cfg.MaxRetries = 2
+	cfg.RetryDelay = time.Millisecond
+	cfg.ResponseInspector = inspector
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	resp, err := client.GetPatent(context.Background(), "17248024")
+	if err != nil {
+		t.Fatalf("GetPatent: %v", err)
+	}
+	if got := titleOf((*resp.PatentFileWrapperDataBag)[0].ApplicationMetaData); got != title {
+		t.Errorf("title is %d bytes, want %d: the inspector disturbed the body", len(got), len(title))
+	}
+
+	want := []string{"req-1 503 try again", `req-2 200 {"count":`}
+	if len(seen) != len(want) || seen[0] != want[0] || seen[1] != want[1] {
+		t.Errorf("inspector saw %q, want %q", seen, want)
+	}
+}
@@ -5,8 +5,10 @@ package odp
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
@@ -128,6 +130,173 @@ func TestIntegrationSearchPatents(t *testing.T) {
 	}
 }
 
+func TestIntegrationSearchPatentsWithOptions(t *testing.T) {
+	c := newITClient(t, false)
+	res, err := c.SearchPatentsWithOptions(testCtx(t), "artificial intelligence", 0, 2, &PatentSearchOptions{
+		Sort:   []PatentSearchSort{{Field: "applicationMetaData.filingDate", Order: "Desc"}},
+		Fields: []string{"applicationNumberText", "applicationMetaData.inventionTitle"},
+	})
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("SearchPatentsWithOptions: %v", err)
+	}
+	if res == nil || res.PatentFileWrapperDataBag == nil {
+		t.Fatal("expected non-nil response")
+	}
+	for _, pfw := range *res.PatentFileWrapperDataBag {
+		if pfw.ApplicationNumberText == nil {
+			t.Error("projected record lacks applicationNumberText")
+		}
+	}
+}
+
+func TestIntegrationCountPatents(t *testing.T) {
+	c := newITClient(t, false)
+	n, err := c.CountPatents(testCtx(t), "artificial intelligence")
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("CountPatents: %v", err)
+	}
+	if n <= 0 {
+		t.Errorf("CountPatents = %d, want > 0", n)
+	}
+}
+
+func TestIntegrationSearchPatentsByFilingDateRange(t *testing.T) {
+	c := newITClient(t, false)
+	from := time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.July, 2, 0, 0, 0, 0, time.UTC)
+	res, err := c.SearchPatentsByFilingDateRange(testCtx(t), from, to, 0, 2)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("SearchPatentsByFilingDateRange: %v", err)
+	}
+	if res == nil || res.PatentFileWrapperDataBag == nil {
+		t.Fatal("expected non-nil response")
+	}
+	for _, pfw := range *res.PatentFileWrapperDataBag {
+		if pfw.ApplicationMetaData == nil || pfw.ApplicationMetaData.FilingDate == nil {
+			continue
+		}
+		if d := *pfw.ApplicationMetaData.FilingDate; d < "2025-07-01" || d > "2025-07-02" {
+			t.Errorf("filing date %s outside requested range", d)
+		}
+	}
+}
+
+func TestIntegrationSearchPatentsGET(t *testing.T) {
+	c := newITClient(t, false)
+	res, err := c.SearchPatentsGET(testCtx(t), "applicationMetaData.inventionTitle:electrode", 0, 2)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("SearchPatentsGET: %v", err)
+	}
+	if res == nil || res.PatentFileWrapperDataBag == nil {
+		t.Fatal("expected non-nil response")
+	}
+}
+
+func TestIntegrationSearchPatentsAdvanced(t *testing.T) {
+	c := newITClient(t, false)
+	desc := generated.SortOrderDesc
+	res, err := c.SearchPatentsAdvanced(testCtx(t), generated.PatentSearchRequest{
+		Q:          StringPtr("applicationMetaData.inventionTitle:electrode"),
+		Sort:       &[]generated.Sort{{Field: StringPtr("applicationMetaData.filingDate"), Order: &desc}},
+		Pagination: &generated.Pagination{Offset: Int32Ptr(0), Limit: Int32Ptr(3)},
+	})
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("SearchPatentsAdvanced: %v", err)
+	}
+	if res == nil || res.PatentFileWrapperDataBag == nil {
+		t.Fatal("expected non-nil response")
+	}
+	prev := ""
+	for _, pfw := range *res.PatentFileWrapperDataBag {
+		if pfw.ApplicationMetaData == nil || pfw.ApplicationMetaData.FilingDate == nil {
+			continue
+		}
+		d := *pfw.ApplicationMetaData.FilingDate
+		if prev != "" && d > prev {
+			t.Errorf("filing dates not descending: %s after %s", d, prev)
+		}
+		prev = d
+	}
+}
+
+func TestIntegrationSearchPatentsStreamDecode(t *testing.T) {
+	c := newITClient(t, false)
+	n := 0
+	err := c.SearchPatentsStreamDecode(testCtx(t), generated.PatentSearchRequest{
+		Q:          StringPtr("applicationMetaData.inventionTitle:electrode"),
+		Pagination: &generated.Pagination{Offset: Int32Ptr(0), Limit: Int32Ptr(3)},
+	}, func(rec *PatentFileWrapper) error {
+		if rec.ApplicationNumberText == nil {
+			t.Error("record without applicationNumberText")
+		}
+		n++
+		return nil
+	})
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("SearchPatentsStreamDecode: %v", err)
+	}
+	if n == 0 || n > 3 {
+		t.Errorf("callback ran %d times, want 1-3", n)
+	}
+}
+
+func TestIntegrationSearchPatentsFromCursor(t *testing.T) {
+	c := newITClient(t, false)
+	cursor := NewSearchCursor("applicationMetaData.inventionTitle:electrode", nil)
+	first, err := c.SearchPatentsFromCursor(testCtx(t), cursor, 2)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("SearchPatentsFromCursor: %v", err)
+	}
+	if first == nil || first.PatentFileWrapperDataBag == nil {
+		t.Fatal("expected non-nil response")
+	}
+	if want := len(*first.PatentFileWrapperDataBag); cursor.Offset != want {
+		t.Errorf("cursor offset = %d, want %d after first page", cursor.Offset, want)
+	}
+	if cursor.Done {
+		return
+	}
+	second, err := c.SearchPatentsFromCursor(testCtx(t), cursor, 2)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("SearchPatentsFromCursor (resume): %v", err)
+	}
+	seen := map[string]bool{}
+	for _, pfw := range *first.PatentFileWrapperDataBag {
+		seen[derefStr(pfw.ApplicationNumberText)] = true
+	}
+	if second.PatentFileWrapperDataBag != nil {
+		for _, pfw := range *second.PatentFileWrapperDataBag {
+			if app := derefStr(pfw.ApplicationNumberText); seen[app] {
+				t.Errorf("application %s returned on both pages", app)
+			}
+		}
+	}
+}
+
 func TestIntegrationResolvePatentNumber(t *testing.T) {
 	c := newITClient(t, false)
 	app, err := c.ResolvePatentNumber(testCtx(t), "US 11,646,472 B2")
@@ -198,6 +367,62 @@ func TestIntegrationGetPatentContinuity(t *testing.T) {
 	}
 }
 
+func TestIntegrationGrantNumberForApplication(t *testing.T) {
+	c := newITClient(t, false)
+	number, granted, err := c.GrantNumberForApplication(testCtx(t), itApp)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("GrantNumberForApplication: %v", err)
+	}
+	if !granted || number != "11646472" {
+		t.Errorf("GrantNumberForApplication(%s) = %q, %v, want 11646472, true", itApp, number, granted)
+	}
+}
+
+func TestIntegrationGetPatentFamily(t *testing.T) {
+	c := newITClient(t, false)
+	tree, err := c.GetPatentFamily(testCtx(t), itApp, 2)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("GetPatentFamily: %v", err)
+	}
+	if tree == nil || !tree.Contains(itApp) {
+		t.Fatalf("family %+v does not contain the root %s", tree, itApp)
+	}
+}
+
+func TestIntegrationGetPatentDocumentsIncludingParents(t *testing.T) {
+	c := newITClient(t, false)
+	docs, err := c.GetPatentDocumentsIncludingParents(testCtx(t), itApp, 1)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("GetPatentDocumentsIncludingParents: %v", err)
+	}
+	if len(docs) == 0 || docs[0].ApplicationNumber != itApp {
+		t.Fatalf("expected the application's own documents first, got %d docs", len(docs))
+	}
+}
+
+func TestIntegrationAreRelated(t *testing.T) {
+	c := newITClient(t, false)
+	related, err := c.AreRelated(testCtx(t), itApp, itApp)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("AreRelated: %v", err)
+	}
+	if !related {
+		t.Error("an application should be related to itself")
+	}
+}
+
 func TestIntegrationGetPatentDocuments(t *testing.T) {
 	c := newITClient(t, false)
 	res, err := c.GetPatentDocuments(testCtx(t), itApp)
@@ -212,6 +437,20 @@ func TestIntegrationGetPatentDocuments(t *testing.T) {
 	}
 }
 
+func TestIntegrationGetPatentDocumentCodes(t *testing.T) {
+	c := newITClient(t, false)
+	codes, err := c.GetPatentDocumentCodes(testCtx(t), itApp)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("GetPatentDocumentCodes: %v", err)
+	}
+	if len(codes) == 0 {
+		t.Fatal("expected at least one document code")
+	}
+}
+
 func TestIntegrationGetPatentAssignment(t *testing.T) {
 	c := newITClient(t, false)
 	res, err := c.GetPatentAssignment(testCtx(t), itApp)
@@ -226,6 +465,34 @@ func TestIntegrationGetPatentAssignment(t *testing.T) {
 	}
 }
 
+func TestIntegrationEarliestUSFilingDate(t *testing.T) {
+	c := newITClient(t, false)
+	date, app, err := c.EarliestUSFilingDate(testCtx(t), itApp)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("EarliestUSFilingDate: %v", err)
+	}
+	if date.IsZero() || app == "" {
+		t.Errorf("EarliestUSFilingDate = %v, %q; want a date and application", date, app)
+	}
+}
+
+func TestIntegrationSearchAssignments(t *testing.T) {
+	c := newITClient(t, false)
+	res, total, err := c.SearchAssignments(testCtx(t), "applicationNumberText:"+itApp, 0, 1)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("SearchAssignments: %v", err)
+	}
+	if len(res) > total {
+		t.Errorf("got %d results for a total of %d", len(res), total)
+	}
+}
+
 func TestIntegrationGetPatentAssociatedDocuments(t *testing.T) {
 	c := newITClient(t, false)
 	res, err := c.GetPatentAssociatedDocuments(testCtx(t), itApp)
@@ -282,6 +549,38 @@ func TestIntegrationGetPatentTransactions(t *testing.T) {
 	}
 }
 
+func TestIntegrationGetPatentDossier(t *testing.T) {
+	c := newITClient(t, false)
+	d, err := c.GetPatentDossier(testCtx(t), itApp, &DossierOptions{Claims: true})
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("GetPatentDossier: %v", err)
+	}
+	if d.ApplicationNumber != itApp || d.Record == nil {
+		t.Errorf("dossier = %s/%v, want %s with a record", d.ApplicationNumber, d.Record != nil, itApp)
+	}
+	if len(d.Claims) == 0 {
+		t.Error("expected claims from the grant XML")
+	}
+}
+
+func TestIntegrationExportPatentDossier(t *testing.T) {
+	c := newITClient(t, false)
+	var buf bytes.Buffer
+	err := c.ExportPatentDossier(testCtx(t), itApp, &buf, nil)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("ExportPatentDossier: %v", err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		t.Error("dossier export is not valid JSON")
+	}
+}
+
 func TestIntegrationSearchPatentsDownload(t *testing.T) {
 	c := newITClient(t, false)
 	format := generated.PatentDownloadRequestFormat("json")
@@ -316,6 +615,21 @@ func TestIntegrationGetStatusCodes(t *testing.T) {
 	}
 }
 
+func TestIntegrationEnrichWithStatusDescriptions(t *testing.T) {
+	c := newITClient(t, false)
+	w := &PatentFileWrapper{ApplicationMetaData: &generated.ApplicationMetaData{ApplicationStatusCode: IntPtr(150)}}
+	err := c.EnrichWithStatusDescriptions(testCtx(t), []*PatentFileWrapper{w})
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("EnrichWithStatusDescriptions: %v", err)
+	}
+	if w.ApplicationMetaData.ApplicationStatusDescriptionText == nil {
+		t.Error("expected a description for status 150")
+	}
+}
+
 // --- Patent XML --------------------------------------------------------------
 
 func TestIntegrationGetXMLURLForApplication(t *testing.T) {
@@ -332,6 +646,37 @@ func TestIntegrationGetXMLURLForApplication(t *testing.T) {
 	}
 }
 
+func TestIntegrationGetGrantXMLURL(t *testing.T) {
+	c := newITClient(t, false)
+	url, err := c.GetGrantXMLURL(testCtx(t), itApp)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("GetGrantXMLURL: %v", err)
+	}
+	if !strings.HasPrefix(url, "https://") {
+		t.Errorf("expected https URL, got %q", url)
+	}
+}
+
+func TestIntegrationGetApplicationXMLURL(t *testing.T) {
+	c := newITClient(t, false)
+	url, err := c.GetApplicationXMLURL(testCtx(t), itApp)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil && strings.Contains(err.Error(), "no pre-grant publication") {
+		t.Skipf("application was not published before grant: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("GetApplicationXMLURL: %v", err)
+	}
+	if !strings.HasPrefix(url, "https://") {
+		t.Errorf("expected https URL, got %q", url)
+	}
+}
+
 func TestIntegrationGetPatentXML(t *testing.T) {
 	c := newITClient(t, false)
 	doc, err := c.GetPatentXML(testCtx(t), itApp)
@@ -346,6 +691,23 @@ func TestIntegrationGetPatentXML(t *testing.T) {
 	}
 }
 
+func TestIntegrationGetPatentWithXML(t *testing.T) {
+	c := newITClient(t, false)
+	res, doc, err := c.GetPatentWithXML(testCtx(t), itApp)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("GetPatentWithXML: %v", err)
+	}
+	if res == nil || doc == nil {
+		t.Fatal("expected non-nil patent record and XML document")
+	}
+	if doc.GetTitle() == "" {
+		t.Error("expected a non-empty title")
+	}
+}
+
 func TestIntegrationDownloadXML(t *testing.T) {
 	c := newITClient(t, false)
 	url, _, err := c.GetXMLURLForApplication(testCtx(t), itApp)
@@ -388,6 +750,50 @@ func TestIntegrationDownloadXMLWithType(t *testing.T) {
 	}
 }
 
+func TestIntegrationDownloadXMLWithProgress(t *testing.T) {
+	c := newITClient(t, false)
+	url, dt, err := c.GetXMLURLForApplication(testCtx(t), itApp)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("GetXMLURLForApplication (chain): %v", err)
+	}
+	var last int64
+	doc, err := c.DownloadXMLWithProgress(testCtx(t), url, dt, func(done, _ int64) { last = done })
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("DownloadXMLWithProgress: %v", err)
+	}
+	if doc == nil || last == 0 {
+		t.Fatalf("expected a document and progress, got %v after %d bytes", doc, last)
+	}
+}
+
+func TestIntegrationGetPatentDocumentsFiltered(t *testing.T) {
+	c := newITClient(t, false)
+	res, err := c.GetPatentDocumentsFiltered(testCtx(t), itApp, DocumentFilter{
+		Codes:     []string{"CTNF", "CTFR", "NOA"},
+		Direction: "OUTGOING",
+	})
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("GetPatentDocumentsFiltered: %v", err)
+	}
+	if res == nil {
+		t.Fatal("expected non-nil response")
+	}
+	for _, d := range documentsFromBag(itApp, res) {
+		if d.Direction != "OUTGOING" {
+			t.Errorf("document %s (%s) has direction %q", d.Identifier, d.Code, d.Direction)
+		}
+	}
+}
+
 func TestIntegrationDownloadPatentDocument(t *testing.T) {
 	c := newITClient(t, false)
 	// Chain: find a document with a PDF download URL from the documents listing.
@@ -415,6 +821,40 @@ func TestIntegrationDownloadPatentDocument(t *testing.T) {
 	}
 }
 
+func TestIntegrationDownloadPatentDocumentByID(t *testing.T) {
+	c := newITClient(t, false)
+	// Chain: take the identifier of a listed document with a PDF download.
+	docs, err := c.GetPatentDocuments(testCtx(t), itApp)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("GetPatentDocuments (chain): %v", err)
+	}
+	var id string
+	for _, d := range documentsFromBag(itApp, docs) {
+		for _, dl := range d.Downloads {
+			if dl.MimeType == "PDF" && id == "" {
+				id = d.Identifier
+			}
+		}
+	}
+	if id == "" {
+		t.Skip("skip: no PDF document available in documents listing")
+	}
+	var buf bytes.Buffer
+	err = c.DownloadPatentDocumentByID(testCtx(t), itApp, id, "PDF", &buf)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("DownloadPatentDocumentByID: %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF")) {
+		t.Fatalf("expected PDF bytes, got %d bytes", buf.Len())
+	}
+}
+
 // firstPatentDocURL returns the first PDF downloadUrl in a documents bag, or "".
 func firstPatentDocURL(docs *generated.DocumentBag) string {
 	if docs == nil || docs.DocumentBag == nil {
@@ -463,6 +903,23 @@ func TestIntegrationGetBulkProduct(t *testing.T) {
 	}
 }
 
+func TestIntegrationGetBulkProductSummary(t *testing.T) {
+	c := newITClient(t, false)
+	summary, err := c.GetBulkProductSummary(testCtx(t), itBulkProduct)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("GetBulkProductSummary: %v", err)
+	}
+	if summary.ProductID != itBulkProduct {
+		t.Errorf("ProductID = %q, want %q", summary.ProductID, itBulkProduct)
+	}
+	if summary.FileCount == 0 || summary.TotalSize == 0 {
+		t.Errorf("expected file count and total size, got %+v", summary)
+	}
+}
+
 func TestIntegrationDownloadBulkFile(t *testing.T) {
 	c := newITClient(t, false)
 	// Bulk files are multi-hundred-MB ZIPs; only run the full download when
@@ -494,6 +951,35 @@ func TestIntegrationDownloadBulkFile(t *testing.T) {
 	}
 }
 
+func TestIntegrationDownloadBulkFileToPath(t *testing.T) {
+	c := newITClient(t, false)
+	res, err := c.GetBulkProduct(testCtx(t), itBulkProduct)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("GetBulkProduct (chain): %v", err)
+	}
+	uri := firstBulkFileURI(res)
+	if uri == "" {
+		t.Skip("skip: no FileDownloadURI available")
+	}
+	if os.Getenv("TEST_BULK_DOWNLOAD") != "true" {
+		t.Skip("skip: bulk file download is large; set TEST_BULK_DOWNLOAD=true to run")
+	}
+	dest := filepath.Join(t.TempDir(), "bulk.zip")
+	err = c.DownloadBulkFileToPath(testCtx(t), uri, dest, nil)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("DownloadBulkFileToPath: %v", err)
+	}
+	if info, err := os.Stat(dest); err != nil || info.Size() == 0 {
+		t.Fatalf("expected a non-empty file at %s (err %v)", dest, err)
+	}
+}
+
 func TestIntegrationDownloadBulkFileWithProgress(t *testing.T) {
 	c := newITClient(t, false)
 	res, err := c.GetBulkProduct(testCtx(t), itBulkProduct)
@@ -523,7 +1009,131 @@ func TestIntegrationDownloadBulkFileWithProgress(t *testing.T) {
 	}
 }
 
+func TestIntegrationDownloadBulkFileResume(t *testing.T) {
+	c := newITClient(t, false)
+	res, err := c.GetBulkProduct(testCtx(t), itBulkProduct)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("GetBulkProduct (chain): %v", err)
+	}
+	uri := firstBulkFileURI(res)
+	if uri == "" {
+		t.Skip("skip: no FileDownloadURI available")
+	}
+	if os.Getenv("TEST_BULK_DOWNLOAD") != "true" {
+		t.Skip("skip: bulk file download is large; set TEST_BULK_DOWNLOAD=true to run")
+	}
+	// Resume past the first KiB; the server must honor the range.
+	f, err := os.Create(t.TempDir() + "/resume.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	err = c.DownloadBulkFileResume(testCtx(t), uri, f, 1024)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("DownloadBulkFileResume: %v", err)
+	}
+	if info, err := f.Stat(); err != nil || info.Size() <= 1024 {
+		t.Fatalf("expected file larger than the resume offset, got %v (%v)", info, err)
+	}
+}
+
+func TestIntegrationDownloadBulkFileParallel(t *testing.T) {
+	c := newITClient(t, false)
+	res, err := c.GetBulkProduct(testCtx(t), itBulkProduct)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("GetBulkProduct (chain): %v", err)
+	}
+	uri := firstBulkFileURI(res)
+	if uri == "" {
+		t.Skip("skip: no FileDownloadURI available")
+	}
+	if os.Getenv("TEST_BULK_DOWNLOAD") != "true" {
+		t.Skip("skip: bulk file download is large; set TEST_BULK_DOWNLOAD=true to run")
+	}
+	f, err := os.Create(t.TempDir() + "/parallel.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	err = c.DownloadBulkFileParallel(testCtx(t), uri, f, 4)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("DownloadBulkFileParallel: %v", err)
+	}
+	if info, err := f.Stat(); err != nil || info.Size() == 0 {
+		t.Fatalf("expected non-empty file, got %v (%v)", info, err)
+	}
+}
+
+func TestIntegrationDownloadBulkFileVerified(t *testing.T) {
+	c := newITClient(t, false)
+	res, err := c.GetBulkProduct(testCtx(t), itBulkProduct)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("GetBulkProduct (chain): %v", err)
+	}
+	uri := firstBulkFileURI(res)
+	if uri == "" {
+		t.Skip("skip: no FileDownloadURI available")
+	}
+	if os.Getenv("TEST_BULK_DOWNLOAD") != "true" {
+		t.Skip("skip: bulk file download is large; set TEST_BULK_DOWNLOAD=true to run")
+	}
+	// No published digest to compare against; a size of 1 must fail the
+	// check after the stream completes.
+	var buf bytes.Buffer
+	err = c.DownloadBulkFileVerified(testCtx(t), uri, &buf, BulkFileExpectation{Size: 1}, nil)
+	if skipExpected(t, err) {
+		return
+	}
+	var ie *IntegrityError
+	if !errors.As(err, &ie) || ie.Check != "size" {
+		t.Fatalf("DownloadBulkFileVerified: err = %v, want a size IntegrityError", err)
+	}
+}
+
 // firstBulkFileURI returns the first FileDownloadURI in a product bag, or "".
+func TestIntegrationGetBulkFileURL(t *testing.T) {
+	c := newITClient(t, false)
+	res, err := c.GetBulkProduct(testCtx(t), itBulkProduct)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("GetBulkProduct (chain): %v", err)
+	}
+	uri := firstBulkFileURI(res)
+	_, path, ok := strings.Cut(uri, "/api/v1/datasets/products/files/")
+	product, fileName, ok2 := strings.Cut(path, "/")
+	if !ok || !ok2 {
+		t.Skipf("skip: no FileDownloadURI available (got %q)", uri)
+	}
+	// Only the redirect is requested; nothing is downloaded.
+	signed, err := c.GetBulkFileURL(testCtx(t), product, fileName)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("GetBulkFileURL: %v", err)
+	}
+	if !strings.HasPrefix(signed, "https://") {
+		t.Errorf("GetBulkFileURL = %q, want an https URL", signed)
+	}
+}
+
 func firstBulkFileURI(res *generated.BdssResponseProductBag) string {
 	if res == nil || res.BulkDataProductBag == nil {
 		return ""
@@ -557,6 +1167,114 @@ func TestIntegrationSearchPetitions(t *testing.T) {
 	}
 }
 
+func TestIntegrationSearchPetitionsAdvanced(t *testing.T) {
+	c := newITClient(t, false)
+	desc := generated.PetitionDecisionSortOrderDesc
+	res, err := c.SearchPetitionsAdvanced(testCtx(t), generated.PetitionDecisionSearchRequest{
+		Q: StringPtr("revival"),
+		RangeFilters: &[]generated.PetitionDecisionRange{
+			{Field: StringPtr("petitionMailDate"), ValueFrom: StringPtr("2020-01-01"), ValueTo: StringPtr("2020-12-31")},
+		},
+		Sort:       &[]generated.PetitionDecisionSort{{Field: StringPtr("petitionMailDate"), Order: &desc}},
+		Pagination: &generated.Pagination{Offset: Int32Ptr(0), Limit: Int32Ptr(3)},
+	})
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("SearchPetitionsAdvanced: %v", err)
+	}
+	if res == nil || res.PetitionDecisionDataBag == nil {
+		t.Fatal("expected non-nil response")
+	}
+	for _, p := range *res.PetitionDecisionDataBag {
+		if p.PetitionMailDate == nil {
+			continue
+		}
+		if d := *p.PetitionMailDate; d < "2020-01-01" || d > "2020-12-31" {
+			t.Errorf("petitionMailDate %s outside the 2020 range", d)
+		}
+	}
+}
+
+func TestIntegrationSearchPetitionsAll(t *testing.T) {
+	c := newITClient(t, false)
+	// Read a little over one small page to exercise the second fetch.
+	n := 0
+	for d, err := range c.SearchPetitionsAll(testCtx(t), "revival", 2) {
+		if skipExpected(t, err) {
+			return
+		}
+		if err != nil {
+			t.Fatalf("SearchPetitionsAll: %v", err)
+		}
+		if d.PetitionDecisionRecordIdentifier == nil {
+			t.Error("decision without a record identifier")
+		}
+		if n++; n == 3 {
+			break
+		}
+	}
+	if n == 0 {
+		t.Error("expected at least one decision")
+	}
+}
+
+func TestIntegrationCountPetitions(t *testing.T) {
+	c := newITClient(t, false)
+	n, err := c.CountPetitions(testCtx(t), "revival")
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("CountPetitions: %v", err)
+	}
+	if n <= 0 {
+		t.Errorf("CountPetitions = %d, want > 0", n)
+	}
+}
+
+func TestIntegrationGetPetitionsForApplication(t *testing.T) {
+	c := newITClient(t, false)
+	// Take the application of a known petition so the lookup has a hit.
+	found, err := c.SearchPetitions(testCtx(t), "revival", 0, 1)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("SearchPetitions (chain): %v", err)
+	}
+	if found == nil || found.PetitionDecisionDataBag == nil || len(*found.PetitionDecisionDataBag) == 0 ||
+		(*found.PetitionDecisionDataBag)[0].ApplicationNumberText == nil {
+		t.Skip("skip: no petition with an application number available")
+	}
+	appNo := *(*found.PetitionDecisionDataBag)[0].ApplicationNumberText
+	res, err := c.GetPetitionsForApplication(testCtx(t), appNo)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("GetPetitionsForApplication: %v", err)
+	}
+	if res == nil || res.Count == nil || *res.Count == 0 {
+		t.Fatalf("expected at least one petition for %s", appNo)
+	}
+}
+
+func TestIntegrationSearchPetitionsByRule(t *testing.T) {
+	c := newITClient(t, false)
+	res, err := c.SearchPetitionsByRule(testCtx(t), "37 CFR 1.137(a)", 0, 2)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("SearchPetitionsByRule: %v", err)
+	}
+	if res == nil {
+		t.Fatal("expected non-nil response")
+	}
+}
+
 func TestIntegrationGetPetitionDecision(t *testing.T) {
 	c := newITClient(t, false)
 	// Chain: find a petition record identifier from a search.
@@ -595,6 +1313,32 @@ func firstPetitionRecordID(res *generated.PetitionDecisionResponseBag) string {
 	return ""
 }
 
+func TestIntegrationGetPetitionDecisionRaw(t *testing.T) {
+	c := newITClient(t, false)
+	// Chain: find a petition record identifier from a search.
+	search, err := c.SearchPetitions(testCtx(t), "revival", 0, 1)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("SearchPetitions (chain): %v", err)
+	}
+	recordID := firstPetitionRecordID(search)
+	if recordID == "" {
+		t.Skip("skip: no petition record identifier available")
+	}
+	raw, err := c.GetPetitionDecisionRaw(testCtx(t), recordID, false)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("GetPetitionDecisionRaw: %v", err)
+	}
+	if !json.Valid(raw) {
+		t.Errorf("raw body is not valid JSON: %s", truncatePreview(string(raw), 200))
+	}
+}
+
 func TestIntegrationSearchPetitionsDownload(t *testing.T) {
 	c := newITClient(t, false)
 	format := generated.PetitionDecisionDownloadRequestFormat("json")
@@ -1243,3 +1987,44 @@ func TestIntegrationGetTrademarkMultiStatus(t *testing.T) {
 		t.Fatal("expected non-nil response")
 	}
 }
+
+func TestIntegrationForPatent(t *testing.T) {
+	c := newITClient(t, false)
+	ctx := testCtx(t)
+	p, err := c.ForPatent(ctx, itApp)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("ForPatent: %v", err)
+	}
+	if p.ApplicationNumber() != itApp {
+		t.Errorf("ApplicationNumber() = %q, want %q", p.ApplicationNumber(), itApp)
+	}
+	cont, err := p.Continuity(ctx)
+	if skipExpected(t, err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("Continuity: %v", err)
+	}
+	if cont.ApplicationNumber != itApp {
+		t.Errorf("Continuity().ApplicationNumber = %q, want %q", cont.ApplicationNumber, itApp)
+	}
+}
+
+func TestIntegrationGetPatents(t *testing.T) {
+	c := newITClient(t, false)
+	results, errs := c.GetPatents(testCtx(t), []string{itApp, itApp}, 2)
+	for i, err := range errs {
+		if skipExpected(t, err) {
+			return
+		}
+		if err != nil {
+			t.Fatalf("GetPatents[%d]: %v", i, err)
+		}
+		if results[i] == nil || results[i].PatentFileWrapperDataBag == nil {
+			t.Errorf("GetPatents[%d] returned no record", i)
+		}
+	}
+}
@@ -519,7 +519,7 @@ func TestXMLParsing(t *testing.T) {
 		APIKey:     apiKey,
 		MaxRetries: 2,
 		RetryDelay: 1 * time.Second,
-		Timeout:    60 * time.Second, // Longer timeout for XML downloads
+		// No Timeout: the deadline of testCtx bounds the XML downloads.
 	}
 
 	client, err := NewClient(config)
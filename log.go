@@ -0,0 +1,41 @@
+package odp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// loggingTransport logs every HTTP exchange at debug level to
+// Config.Logger. Only the host and path are logged: query strings can carry
+// signed download tokens.
+type loggingTransport struct {
+	base   http.RoundTripper
+	logger *slog.Logger
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("host", req.URL.Host),
+		slog.String("path", req.URL.Path),
+		slog.Duration("latency", time.Since(start)),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+	} else {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+	t.logger.LogAttrs(req.Context(), slog.LevelDebug, "odp: http request", attrs...)
+	return resp, err
+}
+
+// debug logs msg at debug level to Config.Logger, if one is set.
+func (c *Client) debug(ctx context.Context, msg string, attrs ...slog.Attr) {
+	if c.config.Logger != nil {
+		c.config.Logger.LogAttrs(ctx, slog.LevelDebug, msg, attrs...)
+	}
+}
@@ -0,0 +1,125 @@
+package odp
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConfigLogger(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		writeWrapperBag(w, "17248024", "Electrode protection")
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "secret-key"
+	cfg.MaxRetries = 2
+	cfg.RetryDelay = time.Millisecond
+	cfg.Logger = logger
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if _, err := client.GetPatent(context.Background(), "17248024"); err != nil {
+		t.Fatalf("GetPatent: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d log lines, want 3 (request, retry, request):\n%s", len(lines), buf.String())
+	}
+	for i, want := range []string{
+		`msg="odp: http request" method=GET`,
+		`msg="odp: retrying request" attempt=1 max_retries=2`,
+		`msg="odp: http request" method=GET`,
+	} {
+		if !strings.Contains(lines[i], want) {
+			t.Errorf("line %d = %s, want it to contain %s", i, lines[i], want)
+		}
+	}
+	if !strings.Contains(lines[0], "path=/api/v1/patent/applications/17248024") || !strings.Contains(lines[0], "status=502") {
+		t.Errorf("first request line = %s, want the path and status=502", lines[0])
+	}
+	if !strings.Contains(lines[2], "status=200") {
+		t.Errorf("second request line = %s, want status=200", lines[2])
+	}
+	if strings.Contains(buf.String(), "secret-key") {
+		t.Error("log output contains the API key")
+	}
+}
+
+// recordingTransport stands in for an instrumenting transport such as
+// otelhttp's: it sees every attempt along with the caller's context.
+type recordingTransport struct {
+	mu    sync.Mutex
+	spans []string
+}
+
+type spanKey struct{}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	parent, _ := req.Context().Value(spanKey{}).(string)
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	if err == nil {
+		rt.spans = append(rt.spans, parent+" > "+req.URL.Path+" "+strconv.Itoa(resp.StatusCode))
+	}
+	return resp, err
+}
+
+func TestConfigTransport(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		writeWrapperBag(w, "17248024", "Electrode protection")
+	}))
+	defer server.Close()
+
+	rt := &recordingTransport{}
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	cfg.RetryDelay = time.Millisecond
+	cfg.RequestsPerSecond = 100
+	cfg.Transport = rt
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	ctx := context.WithValue(context.Background(), spanKey{}, "caller")
+	if _, err := client.GetPatent(ctx, "17248024"); err != nil {
+		t.Fatalf("GetPatent: %v", err)
+	}
+
+	want := []string{
+		"caller > /api/v1/patent/applications/17248024 503",
+		"caller > /api/v1/patent/applications/17248024 200",
+	}
+	if len(rt.spans) != len(want) || rt.spans[0] != want[0] || rt.spans[1] != want[1] {
+		t.Errorf("transport saw %q, want %q", rt.spans, want)
+	}
+}
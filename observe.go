@@ -0,0 +1,84 @@
+package odp
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CallInfo describes one finished API call - one client method's request,
+// with its retries and fallback - for Config.ObserveCall.
+type CallInfo struct {
+	// Endpoint is the method and path of the call's first HTTP attempt,
+	// e.g. "GET /api/v1/patent/applications/17248024". Empty when no
+	// request was sent.
+	Endpoint string
+	// StatusCode is the HTTP status of the last attempt, or 0 when it got
+	// no response.
+	StatusCode int
+	// Attempts is the number of HTTP requests sent, retries and the
+	// fallback attempt included.
+	Attempts int
+	Duration time.Duration
+	// Err is the error the call returned, nil on success.
+	Err error
+}
+
+// callRecord collects what the HTTP attempts of one observed call saw.
+type callRecord struct {
+	mu       sync.Mutex
+	endpoint string
+	status   int
+	attempts int
+}
+
+type callRecordKey struct{}
+
+// observeCall runs fn as one API call, reporting it to Config.ObserveCall
+// when that is set. fn must send its requests with the context it is given.
+func (c *Client) observeCall(ctx context.Context, fn func(ctx context.Context) error) error {
+	if c.config.ObserveCall == nil {
+		return fn(ctx)
+	}
+	start := time.Now()
+	ctx, done := c.config.ObserveCall(ctx)
+	rec := &callRecord{}
+	err := fn(context.WithValue(ctx, callRecordKey{}, rec))
+	if done != nil {
+		rec.mu.Lock()
+		info := CallInfo{
+			Endpoint:   rec.endpoint,
+			StatusCode: rec.status,
+			Attempts:   rec.attempts,
+			Duration:   time.Since(start),
+			Err:        err,
+		}
+		rec.mu.Unlock()
+		done(info)
+	}
+	return err
+}
+
+// observingTransport feeds each HTTP attempt into the callRecord of the
+// call it belongs to. It is only installed when Config.ObserveCall is set.
+type observingTransport struct {
+	base http.RoundTripper
+}
+
+func (t *observingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if rec, _ := req.Context().Value(callRecordKey{}).(*callRecord); rec != nil {
+		rec.mu.Lock()
+		rec.attempts++
+		if rec.endpoint == "" {
+			rec.endpoint = req.Method + " " + req.URL.Path
+		}
+		rec.status = 0
+		if err == nil {
+			rec.status = resp.StatusCode
+		}
+		rec.mu.Unlock()
+	}
+	return resp, err
+}
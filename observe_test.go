@@ -0,0 +1,74 @@
+package odp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type observeKey struct{}
+
+func TestObserveCall(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/patent/applications/19999999/meta-data" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// The first attempt of each search fails, the retry succeeds.
+		if hits.Add(1)%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":0,"patentFileWrapperDataBag":[]}`))
+	}))
+	defer server.Close()
+
+	var calls []CallInfo
+	var sawCtx bool
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test-key"
+	cfg.MaxRetries = 2
+	cfg.RetryDelay = time.Millisecond
+	cfg.ObserveCall = func(ctx context.Context) (context.Context, func(CallInfo)) {
+		return context.WithValue(ctx, observeKey{}, true), func(ci CallInfo) { calls = append(calls, ci) }
+	}
+	cfg.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		// Requests carry the context ObserveCall returned.
+		sawCtx = req.Context().Value(observeKey{}) == true
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	if _, err := client.SearchPatents(context.Background(), "x", 0, 1); err != nil {
+		t.Fatalf("SearchPatents: %v", err)
+	}
+	if !sawCtx {
+		t.Error("request context does not carry the ObserveCall context")
+	}
+	if len(calls) != 1 {
+		t.Fatalf("observed %d calls, want 1", len(calls))
+	}
+	if c := calls[0]; c.Endpoint != "POST /api/v1/patent/applications/search" || c.StatusCode != http.StatusOK || c.Attempts != 2 || c.Err != nil {
+		t.Errorf("call = %+v, want the search endpoint, 200 after 2 attempts", c)
+	}
+
+	if _, err := client.GetPatentMetaData(context.Background(), "19999999"); err == nil {
+		t.Fatal("expected a 404")
+	}
+	if c := calls[len(calls)-1]; c.StatusCode != http.StatusNotFound || c.Attempts != 1 || c.Err == nil {
+		t.Errorf("call = %+v, want one 404 attempt with its error", c)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
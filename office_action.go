@@ -48,7 +48,7 @@ func readJSONResponse(resp *http.Response, result any) error {
 	if err != nil {
 		return fmt.Errorf("reading response: %w", err)
 	}
-	if err := checkResponseStatus(resp.StatusCode, body, resp.Header); err != nil {
+	if err := checkResponseStatus(resp.StatusCode, body, resp); err != nil {
 		return err
 	}
 	if err := checkEmptyBody(resp.StatusCode, body); err != nil {
@@ -63,7 +63,7 @@ func (c *Client) dsapiSearch(ctx context.Context,
 	doRequest func(context.Context) (*http.Response, error),
 ) (*DSAPIResponse, error) {
 	var result DSAPIResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryLoop(ctx, func(ctx context.Context) error {
 		result = DSAPIResponse{}
 		resp, err := doRequest(ctx)
 		if err != nil {
@@ -82,7 +82,7 @@ func (c *Client) dsapiFields(ctx context.Context,
 	doRequest func(context.Context) (*http.Response, error),
 ) (*DSAPIFieldsResponse, error) {
 	var result DSAPIFieldsResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryLoop(ctx, func(ctx context.Context) error {
 		result = DSAPIFieldsResponse{}
 		resp, err := doRequest(ctx)
 		if err != nil {
@@ -0,0 +1,100 @@
+package odp
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Option sets one field of the Config a client is built from; see
+// NewClientWithOptions.
+type Option func(*Config)
+
+// NewClientWithOptions creates a client from DefaultConfig with opts
+// applied in order, for when only a few settings differ from the defaults:
+//
+//	client, err := odp.NewClientWithOptions(odp.WithAPIKey(key))
+//
+// Settings without an option of their own are set through a Config and
+// NewClient.
+func NewClientWithOptions(opts ...Option) (*Client, error) {
+	config := DefaultConfig()
+	for _, opt := range opts {
+		opt(config)
+	}
+	return NewClient(config)
+}
+
+// WithAPIKey sets Config.APIKey.
+func WithAPIKey(key string) Option {
+	return func(c *Config) { c.APIKey = key }
+}
+
+// WithBaseURL sets Config.BaseURL.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Config) { c.BaseURL = baseURL }
+}
+
+// WithFallbackBaseURL sets Config.FallbackBaseURL.
+func WithFallbackBaseURL(baseURL string) Option {
+	return func(c *Config) { c.FallbackBaseURL = baseURL }
+}
+
+// WithTimeout sets Config.Timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Config) { c.Timeout = d }
+}
+
+// WithMaxRetries sets Config.MaxRetries.
+func WithMaxRetries(n int) Option {
+	return func(c *Config) { c.MaxRetries = n }
+}
+
+// WithRetryDelay sets Config.RetryDelay.
+func WithRetryDelay(d time.Duration) Option {
+	return func(c *Config) { c.RetryDelay = d }
+}
+
+// WithUserAgent sets Config.UserAgent.
+func WithUserAgent(ua string) Option {
+	return func(c *Config) { c.UserAgent = ua }
+}
+
+// WithRateLimit sets Config.RequestsPerSecond and Config.Burst.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Config) {
+		c.RequestsPerSecond = requestsPerSecond
+		c.Burst = burst
+	}
+}
+
+// WithTransport sets Config.Transport.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Config) { c.Transport = rt }
+}
+
+// WithHTTPClient takes the Transport and Timeout of hc. The client always
+// builds its own http.Client around that transport, so hc's other fields,
+// such as Jar and CheckRedirect, are not used. A zero hc.Timeout keeps the
+// default Timeout.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Config) {
+		if hc == nil {
+			return
+		}
+		c.Transport = hc.Transport
+		if hc.Timeout > 0 {
+			c.Timeout = hc.Timeout
+		}
+	}
+}
+
+// WithLogger sets Config.Logger.
+func WithLogger(l *slog.Logger) Option {
+	return func(c *Config) { c.Logger = l }
+}
+
+// WithTSDRAPIKey sets Config.TSDRAPIKey, which enables the TSDR methods.
+func WithTSDRAPIKey(key string) Option {
+	return func(c *Config) { c.TSDRAPIKey = key }
+}
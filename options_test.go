@@ -0,0 +1,106 @@
+package odp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewClientWithOptions(t *testing.T) {
+	var gotKey, gotUA string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.Header.Get("X-API-Key")
+		gotUA = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":0,"patentFileWrapperDataBag":[]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithOptions(
+		WithBaseURL(server.URL),
+		WithAPIKey("opt-key"),
+		WithUserAgent("opt-test/1.0"),
+		WithMaxRetries(1),
+		WithTimeout(5*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions: %v", err)
+	}
+	if _, err := client.SearchPatents(context.Background(), "x", 0, 1); err != nil {
+		t.Fatalf("SearchPatents: %v", err)
+	}
+	if gotKey != "opt-key" || gotUA != "opt-test/1.0" {
+		t.Errorf("headers = %q, %q; want the configured key and user agent", gotKey, gotUA)
+	}
+	if client.config.MaxRetries != 1 || client.config.Timeout != 5*time.Second {
+		t.Errorf("config = %d retries, %v timeout", client.config.MaxRetries, client.config.Timeout)
+	}
+	// Fields without an option keep their defaults.
+	if client.config.RetryDelay != DefaultConfig().RetryDelay {
+		t.Errorf("RetryDelay = %v, want the default", client.config.RetryDelay)
+	}
+
+	// Options go through the same validation as NewClient.
+	if _, err := NewClientWithOptions(WithMaxRetries(-1)); err == nil {
+		t.Error("NewClientWithOptions accepted negative MaxRetries")
+	}
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	rt := http.DefaultTransport
+	client, err := NewClientWithOptions(WithHTTPClient(&http.Client{Transport: rt, Timeout: 7 * time.Second}))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions: %v", err)
+	}
+	if client.config.Transport != rt || client.config.Timeout != 7*time.Second {
+		t.Errorf("config = %v, %v; want the http.Client's transport and timeout", client.config.Transport, client.config.Timeout)
+	}
+
+	// A zero Timeout leaves the default in place.
+	client, err = NewClientWithOptions(WithHTTPClient(&http.Client{}))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions: %v", err)
+	}
+	if client.config.Timeout != DefaultConfig().Timeout {
+		t.Errorf("Timeout = %v, want the default", client.config.Timeout)
+	}
+}
+
+func TestWithFallbackBaseURL(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+	var fallbackHits int
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fallbackHits++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":0,"patentFileWrapperDataBag":[]}`))
+	}))
+	defer fallback.Close()
+
+	client, err := NewClientWithOptions(
+		WithBaseURL(primary.URL),
+		WithFallbackBaseURL(fallback.URL),
+		WithAPIKey("opt-key"),
+		WithMaxRetries(0),
+	)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions: %v", err)
+	}
+	if client.config.FallbackBaseURL != fallback.URL {
+		t.Errorf("FallbackBaseURL = %q, want %q", client.config.FallbackBaseURL, fallback.URL)
+	}
+	if _, err := client.SearchPatents(context.Background(), "x", 0, 1); err != nil {
+		t.Fatalf("SearchPatents: %v", err)
+	}
+	if fallbackHits != 1 {
+		t.Errorf("fallback hits = %d, want 1", fallbackHits)
+	}
+
+	if _, err := NewClientWithOptions(WithFallbackBaseURL("not a url")); err == nil {
+		t.Error("NewClientWithOptions accepted an invalid fallback URL")
+	}
+}
@@ -0,0 +1,99 @@
+package odp
+
+import (
+	"context"
+
+	"github.com/patent-dev/uspto-odp/generated"
+)
+
+// PatentClient is a Client bound to one application, returned by
+// ForPatent. The patent number is resolved to its application number once;
+// every method then calls the matching Client method with that number, so
+// fetching several sub-resources costs no further lookups. Like Client, it
+// is safe for concurrent use.
+type PatentClient struct {
+	client            *Client
+	applicationNumber string
+}
+
+// ForPatent resolves patentNumber - in any format GetPatent accepts - to
+// its application number and returns a PatentClient for it:
+//
+//	p, err := client.ForPatent(ctx, "US 11,646,472 B2")
+//	if err != nil {
+//		return err
+//	}
+//	pta, err := p.Adjustment(ctx)
+//	continuity, err := p.Continuity(ctx)
+//
+// A bare 8-digit number is taken as an application number, as in GetPatent;
+// resolve ambiguous user input with ResolvePatentNumber first.
+func (c *Client) ForPatent(ctx context.Context, patentNumber string) (*PatentClient, error) {
+	appNumber, err := c.resolveApplicationNumberLenient(ctx, patentNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &PatentClient{client: c, applicationNumber: appNumber}, nil
+}
+
+// ApplicationNumber returns the resolved application number.
+func (p *PatentClient) ApplicationNumber() string {
+	return p.applicationNumber
+}
+
+// Get retrieves the patent record (see Client.GetPatent).
+func (p *PatentClient) Get(ctx context.Context) (*generated.PatentDataResponse, error) {
+	return p.client.GetPatent(ctx, p.applicationNumber)
+}
+
+// MetaData retrieves the application metadata (see Client.GetPatentMetaData).
+func (p *PatentClient) MetaData(ctx context.Context) (*MetaDataResponse, error) {
+	return p.client.GetPatentMetaData(ctx, p.applicationNumber)
+}
+
+// Adjustment retrieves patent term adjustment data (see Client.GetPatentAdjustment).
+func (p *PatentClient) Adjustment(ctx context.Context) (*AdjustmentResponse, error) {
+	return p.client.GetPatentAdjustment(ctx, p.applicationNumber)
+}
+
+// Continuity retrieves continuity data (see Client.GetPatentContinuity).
+func (p *PatentClient) Continuity(ctx context.Context) (*ContinuityResponse, error) {
+	return p.client.GetPatentContinuity(ctx, p.applicationNumber)
+}
+
+// Documents retrieves the file wrapper document list (see Client.GetPatentDocuments).
+func (p *PatentClient) Documents(ctx context.Context) (*generated.DocumentBag, error) {
+	return p.client.GetPatentDocuments(ctx, p.applicationNumber)
+}
+
+// Transactions retrieves the transaction history (see Client.GetPatentTransactions).
+func (p *PatentClient) Transactions(ctx context.Context) (*TransactionsResponse, error) {
+	return p.client.GetPatentTransactions(ctx, p.applicationNumber)
+}
+
+// Assignment retrieves assignment data (see Client.GetPatentAssignment).
+func (p *PatentClient) Assignment(ctx context.Context) (*AssignmentResponse, error) {
+	return p.client.GetPatentAssignment(ctx, p.applicationNumber)
+}
+
+// AssociatedDocuments retrieves grant and publication XML file metadata
+// (see Client.GetPatentAssociatedDocuments).
+func (p *PatentClient) AssociatedDocuments(ctx context.Context) (*AssociatedDocumentsResponse, error) {
+	return p.client.GetPatentAssociatedDocuments(ctx, p.applicationNumber)
+}
+
+// Attorney retrieves attorney/agent data (see Client.GetPatentAttorney).
+func (p *PatentClient) Attorney(ctx context.Context) (*generated.RecordAttorney, error) {
+	return p.client.GetPatentAttorney(ctx, p.applicationNumber)
+}
+
+// ForeignPriority retrieves foreign priority claims (see Client.GetPatentForeignPriority).
+func (p *PatentClient) ForeignPriority(ctx context.Context) (*ForeignPriorityResponse, error) {
+	return p.client.GetPatentForeignPriority(ctx, p.applicationNumber)
+}
+
+// XML retrieves and parses the full-text grant or publication XML (see
+// Client.GetPatentXML).
+func (p *PatentClient) XML(ctx context.Context) (*XMLDocument, error) {
+	return p.client.GetPatentXML(ctx, p.applicationNumber)
+}
@@ -0,0 +1,78 @@
+package odp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestForPatent_ResolvesOnce(t *testing.T) {
+	dossier := newDossierServer(t, true)
+	defer dossier.Close()
+
+	// Grant searches are counted and answered here; everything else is the
+	// dossier server's application 17248024.
+	var searches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/patent/applications/search" {
+			searches.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"count":1,"patentFileWrapperDataBag":[{"applicationNumberText":"17248024"}]}`))
+			return
+		}
+		dossier.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	ctx := context.Background()
+
+	p, err := client.ForPatent(ctx, "US 11,646,472 B2")
+	if err != nil {
+		t.Fatalf("ForPatent: %v", err)
+	}
+	if got := p.ApplicationNumber(); got != "17248024" {
+		t.Errorf("ApplicationNumber() = %q, want 17248024", got)
+	}
+
+	if _, err := p.Get(ctx); err != nil {
+		t.Errorf("Get: %v", err)
+	}
+	continuity, err := p.Continuity(ctx)
+	if err != nil {
+		t.Fatalf("Continuity: %v", err)
+	}
+	if continuity.ApplicationNumber != "17248024" || len(continuity.Parents) != 1 {
+		t.Errorf("Continuity() = %+v", continuity)
+	}
+	if _, err := p.Transactions(ctx); err != nil {
+		t.Errorf("Transactions: %v", err)
+	}
+	docs, err := p.Documents(ctx)
+	if err != nil || docs.DocumentBag == nil || len(*docs.DocumentBag) != 1 {
+		t.Errorf("Documents() = %+v, %v", docs, err)
+	}
+	if _, err := p.Adjustment(ctx); !isNotFoundErr(err) {
+		t.Errorf("Adjustment: err = %v, want 404", err)
+	}
+	doc, err := p.XML(ctx)
+	if err != nil || doc.GetTitle() == "" {
+		t.Errorf("XML() = %v, %v", doc, err)
+	}
+
+	if n := searches.Load(); n != 1 {
+		t.Errorf("grant number resolved %d times, want 1", n)
+	}
+
+	if _, err := client.ForPatent(ctx, "not a number"); err == nil {
+		t.Error("ForPatent with an invalid number should fail")
+	}
+}
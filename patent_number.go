@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // US grant numbers reached 8 digits at 10,000,000 (June 2018) and, as of 2026, run to
@@ -29,6 +30,85 @@ const (
 	PatentNumberTypePCT
 )
 
+// String returns the lower-case name of the type, e.g. "grant".
+func (t PatentNumberType) String() string {
+	switch t {
+	case PatentNumberTypeApplication:
+		return "application"
+	case PatentNumberTypeGrant:
+		return "grant"
+	case PatentNumberTypePublication:
+		return "publication"
+	case PatentNumberTypePCT:
+		return "pct"
+	default:
+		return "unknown"
+	}
+}
+
+// ApplicationCategory is the kind of application an application number's
+// two-digit series code denotes.
+type ApplicationCategory int
+
+// Application category values.
+const (
+	ApplicationCategoryUnknown                  ApplicationCategory = iota
+	ApplicationCategoryUtility                                      // nonprovisional utility (and plant) series, e.g. 17/
+	ApplicationCategoryProvisional                                  // 60/ through 63/
+	ApplicationCategoryDesign                                       // 29/
+	ApplicationCategoryInternationalDesign                          // 35/, Hague designating the US
+	ApplicationCategoryExParteReexamination                         // 90/
+	ApplicationCategoryInterPartesReexamination                     // 95/
+	ApplicationCategorySupplementalExamination                      // 96/
+)
+
+// String returns the lower-case name of the category, e.g. "design".
+func (c ApplicationCategory) String() string {
+	switch c {
+	case ApplicationCategoryUtility:
+		return "utility"
+	case ApplicationCategoryProvisional:
+		return "provisional"
+	case ApplicationCategoryDesign:
+		return "design"
+	case ApplicationCategoryInternationalDesign:
+		return "international design"
+	case ApplicationCategoryExParteReexamination:
+		return "ex parte reexamination"
+	case ApplicationCategoryInterPartesReexamination:
+		return "inter partes reexamination"
+	case ApplicationCategorySupplementalExamination:
+		return "supplemental examination"
+	default:
+		return "unknown"
+	}
+}
+
+// applicationCategoryOf reads the category from the series code of an
+// 8-digit application number. Reexamination and supplemental examination
+// control numbers share the application number format.
+func applicationCategoryOf(applicationNumber string) ApplicationCategory {
+	if len(applicationNumber) != 8 || !digitsOnlyPattern.MatchString(applicationNumber) {
+		return ApplicationCategoryUnknown
+	}
+	switch applicationNumber[:2] {
+	case "60", "61", "62", "63":
+		return ApplicationCategoryProvisional
+	case "29":
+		return ApplicationCategoryDesign
+	case "35":
+		return ApplicationCategoryInternationalDesign
+	case "90":
+		return ApplicationCategoryExParteReexamination
+	case "95":
+		return ApplicationCategoryInterPartesReexamination
+	case "96":
+		return ApplicationCategorySupplementalExamination
+	default:
+		return ApplicationCategoryUtility
+	}
+}
+
 // PatentNumber represents a normalized patent number
 type PatentNumber struct {
 	Original      string           // Original input
@@ -42,6 +122,17 @@ type PatentNumber struct {
 	// display only -- USPTO's search API ignores grant kind codes when
 	// resolving to an application number.
 	KindCode string
+	// Category is the kind of application the series code denotes, for
+	// application numbers only (e.g. ApplicationCategoryDesign for
+	// 29/123,456). It is ApplicationCategoryUnknown for every other type.
+	Category ApplicationCategory
+	// Prefix is the series letter code of a grant outside the utility series:
+	// "D" (design), "PP" (plant), or "RE" (reissue). It is also the start of
+	// Normalized, e.g. "D987654". Empty for utility patents and all other types.
+	Prefix string
+	// PCT holds the parts of an international application number; nil for
+	// every other type.
+	PCT *PCTNumber
 	// Ambiguous is true when the input was a bare number that could be either a
 	// grant or an application (an 8-digit value with no kind code, slash, or
 	// comma to disambiguate). Resolution probes both interpretations rather than
@@ -49,6 +140,51 @@ type PatentNumber struct {
 	Ambiguous bool
 }
 
+// PCTNumber is an international (PCT) application number split into its
+// parts: PCT/US2023/017788 is receiving office "US", year 2023, serial
+// "017788". Numbers in the legacy form (PCT/US07/19317, before 2004) have a
+// two-digit year, expanded here, and a five-digit serial.
+type PCTNumber struct {
+	Office string // receiving office, e.g. "US"
+	Year   int    // filing year, e.g. 2023
+	Serial string // serial within office and year, with leading zeros
+}
+
+// setPCT fills pn in as a PCT number from the parts a PCT pattern matched
+// and returns it. The application number is the compact form the API
+// takes: "PCT" + office + year + serial, with the year as written.
+func (pn *PatentNumber) setPCT(office, year, serial string) *PatentNumber {
+	office = strings.ToUpper(office)
+	y, _ := strconv.Atoi(year)
+	if len(year) == 2 {
+		// The PCT came into force in 1978.
+		if y >= 78 {
+			y += 1900
+		} else {
+			y += 2000
+		}
+	}
+	pn.Normalized = "PCT" + office + year + serial
+	pn.Type = PatentNumberTypePCT
+	pn.ApplicationNo = pn.Normalized
+	pn.Country = office
+	pn.PCT = &PCTNumber{Office: office, Year: y, Serial: serial}
+	return pn
+}
+
+// normalizePCTApplicationNumber rewrites a PCT application number in any
+// accepted form (PCT/US2023/017788) to the compact form the API takes as a
+// path parameter (PCTUS2023017788). Other numbers are returned unchanged.
+func normalizePCTApplicationNumber(applicationNumber string) string {
+	if !strings.HasPrefix(strings.ToUpper(applicationNumber), "PCT") {
+		return applicationNumber
+	}
+	if pn, err := NormalizePatentNumber(applicationNumber); err == nil && pn.Type == PatentNumberTypePCT {
+		return pn.Normalized
+	}
+	return applicationNumber
+}
+
 // Patent number patterns
 var (
 	// Application with slash: 17/248,024, 17/248024, US 17/248,024
@@ -59,20 +195,29 @@ var (
 	// form (no separators) parses the same as the formatted one.
 	grantWithKindPattern = regexp.MustCompile(`^(?:US)?[\s]*(\d{1,2})[,\s]*(\d{3})[,\s]*(\d{3})[\s]*([A-Z]\d)$`)
 
+	// Design, plant, and reissue grants: D123,456, US D0987654 S, PP12,345 P3,
+	// RE45,678 E. Leading zeros (the padded form used in bulk XML) are dropped
+	// when normalizing.
+	prefixedGrantPattern = regexp.MustCompile(`^(?i)(?:US)?[\s]*(D|PP|RE)[\s]*(\d{1,3}(?:,\d{3})+|\d{1,7})(?:[\s]*([A-Z]\d?))?$`)
+
 	// Grant with comma formatting: 11,646,472, US 11,646,472
 	grantWithCommaPattern = regexp.MustCompile(`^(?:US)?[\s]*(\d{1,2}),(\d{3}),(\d{3})$`)
 
 	// Publication: 20250087686, US20250087686A1, US 2025/0087686 A1
 	publicationPattern = regexp.MustCompile(`^(?:US)?[\s]*(\d{4})[/,\s]*(\d{7})(?:\s*([A-Z]\d))?$`)
 
-	// PCT 15-char API form: PCTUS2025058371 (no slashes anywhere)
-	pct15Pattern = regexp.MustCompile(`^(?i)PCTUS(\d{4})(\d{6})$`)
+	// PCT 15-char API form: PCTUS2025058371 (no slashes anywhere). The
+	// receiving office is any two-letter code; ODP files are US.
+	pct15Pattern = regexp.MustCompile(`^(?i)PCT([A-Z]{2})(\d{4})(\d{6})$`)
 
 	// PCT 17-char display form: PCT/US2025/058371 (slashes after PCT and after year)
-	pct17Pattern = regexp.MustCompile(`^(?i)PCT/US(\d{4})/(\d{6})$`)
+	pct17Pattern = regexp.MustCompile(`^(?i)PCT/([A-Z]{2})(\d{4})/(\d{6})$`)
 
 	// PCT legacy 12-char form: PCTUS0719317 (preserve as-is, API accepts it)
-	pct12Pattern = regexp.MustCompile(`^(?i)PCTUS(\d{7})$`)
+	pct12Pattern = regexp.MustCompile(`^(?i)PCT([A-Z]{2})(\d{2})(\d{5})$`)
+
+	// PCT legacy display form: PCT/US07/19317, as printed before 2004
+	pct12DisplayPattern = regexp.MustCompile(`^(?i)PCT/([A-Z]{2})(\d{2})/(\d{5})$`)
 
 	// Simple patterns for fallback
 	digitsOnlyPattern = regexp.MustCompile(`^\d+$`)
@@ -80,11 +225,15 @@ var (
 
 // NormalizePatentNumber normalizes various patent number formats to application numbers
 // Accepts formats like:
-//   - Application: "17248024", "17/248,024", "17/248024"
+//   - Application: "17248024", "17/248,024", "17/248024", including the
+//     design (29/), reexamination (90/, 95/), and supplemental examination
+//     (96/) series (see PatentNumber.Category)
 //   - Grant: "11646472", "11,646,472", "US 11,646,472 B2"
+//   - Design, plant, and reissue grants: "D123,456", "US D0987654 S",
+//     "PP12,345", "RE45,678 E" (see PatentNumber.Prefix)
 //   - Publication: "20250087686", "US20250087686A1", "US 2025/0087686 A1"
 //   - PCT: "PCTUS2025058371" (15-char API form), "PCT/US2025/058371" (17-char display),
-//     "PCTUS0719317" (12-char legacy)
+//     "PCTUS0719317" or "PCT/US07/19317" (12-char legacy); see PatentNumber.PCT
 func NormalizePatentNumber(input string) (*PatentNumber, error) {
 	if input == "" {
 		return nil, fmt.Errorf("patent number cannot be empty")
@@ -98,27 +247,31 @@ func NormalizePatentNumber(input string) (*PatentNumber, error) {
 		Country:  "US",
 	}
 
-	// Try PCT 15-char API form (no slashes)
-	if matches := pct15Pattern.FindStringSubmatch(cleaned); matches != nil {
-		result.Normalized = "PCTUS" + matches[1] + matches[2]
-		result.Type = PatentNumberTypePCT
-		result.ApplicationNo = result.Normalized
-		return result, nil
+	// Try PCT 15-char API form (no slashes) and 17-char display form
+	// (PCT/US####/######)
+	for _, pattern := range []*regexp.Regexp{pct15Pattern, pct17Pattern} {
+		if matches := pattern.FindStringSubmatch(cleaned); matches != nil {
+			return result.setPCT(matches[1], matches[2], matches[3]), nil
+		}
 	}
 
-	// Try PCT 17-char display form (PCT/US####/######)
-	if matches := pct17Pattern.FindStringSubmatch(cleaned); matches != nil {
-		result.Normalized = "PCTUS" + matches[1] + matches[2]
-		result.Type = PatentNumberTypePCT
-		result.ApplicationNo = result.Normalized
-		return result, nil
+	// Try PCT 12-char legacy form, compact or with slashes
+	for _, pattern := range []*regexp.Regexp{pct12Pattern, pct12DisplayPattern} {
+		if matches := pattern.FindStringSubmatch(cleaned); matches != nil {
+			return result.setPCT(matches[1], matches[2], matches[3]), nil
+		}
 	}
 
-	// Try PCT 12-char legacy form
-	if matches := pct12Pattern.FindStringSubmatch(cleaned); matches != nil {
-		result.Normalized = "PCTUS" + matches[1]
-		result.Type = PatentNumberTypePCT
-		result.ApplicationNo = result.Normalized
+	// Try design, plant, and reissue grants (e.g., D123,456, RE45,678 E)
+	if matches := prefixedGrantPattern.FindStringSubmatch(cleaned); matches != nil {
+		digits := strings.TrimLeft(strings.ReplaceAll(matches[2], ",", ""), "0")
+		if digits == "" {
+			return nil, fmt.Errorf("unrecognized patent number format: %s", input)
+		}
+		result.Prefix = strings.ToUpper(matches[1])
+		result.Normalized = result.Prefix + digits
+		result.Type = PatentNumberTypeGrant
+		result.KindCode = strings.ToUpper(matches[3])
 		return result, nil
 	}
 
@@ -140,6 +293,7 @@ func NormalizePatentNumber(input string) (*PatentNumber, error) {
 		result.Normalized = series + number
 		result.ApplicationNo = series + number
 		result.Type = PatentNumberTypeApplication
+		result.Category = applicationCategoryOf(result.ApplicationNo)
 		return result, nil
 	}
 
@@ -188,6 +342,7 @@ func NormalizePatentNumber(input string) (*PatentNumber, error) {
 			// an unambiguous application, preserving the direct lookup.
 			result.Type = PatentNumberTypeApplication
 			result.ApplicationNo = bare
+			result.Category = applicationCategoryOf(bare)
 			if length == 8 {
 				if n, convErr := strconv.Atoi(bare); convErr == nil &&
 					n >= firstEightDigitGrant && n <= maxEightDigitGrant {
@@ -252,12 +407,17 @@ func (pn *PatentNumber) FormatAsApplication() string {
 	return pn.Normalized
 }
 
-// FormatAsGrant formats number as grant (e.g., 11,646,472)
+// FormatAsGrant formats number as grant (e.g., 11,646,472, or D123,456 for
+// a design patent)
 func (pn *PatentNumber) FormatAsGrant() string {
 	if pn.Type != PatentNumberTypeGrant {
 		return pn.Normalized
 	}
 
+	if pn.Prefix != "" {
+		return pn.Prefix + groupThousands(strings.TrimPrefix(pn.Normalized, pn.Prefix))
+	}
+
 	if len(pn.Normalized) >= 7 {
 		// Handle both 7 and 8 digit grant numbers
 		if len(pn.Normalized) == 7 {
@@ -276,6 +436,19 @@ func (pn *PatentNumber) FormatAsGrant() string {
 	return pn.Normalized
 }
 
+// groupThousands inserts commas between groups of three digits: "987654"
+// becomes "987,654".
+func groupThousands(digits string) string {
+	var b strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
 // FormatAsPublication formats number as publication (e.g., 2025/0087686)
 func (pn *PatentNumber) FormatAsPublication() string {
 	if pn.Type != PatentNumberTypePublication {
@@ -298,11 +471,92 @@ func (pn *PatentNumber) FormatAsPCT() string {
 	if pn.Type != PatentNumberTypePCT {
 		return pn.Normalized
 	}
-	// PCTUS + 4 year + 6 sequence = 15 chars
-	if len(pn.Normalized) == 15 && strings.HasPrefix(pn.Normalized, "PCTUS") {
+	// PCT + 2 office + 4 year + 6 sequence = 15 chars
+	if len(pn.Normalized) == 15 && strings.HasPrefix(pn.Normalized, "PCT") {
+		office := pn.Normalized[3:5]
 		year := pn.Normalized[5:9]
 		seq := pn.Normalized[9:]
-		return fmt.Sprintf("PCT/US%s/%s", year, seq)
+		return fmt.Sprintf("PCT/%s%s/%s", office, year, seq)
 	}
 	return pn.Normalized
 }
+
+// BulkFileName guesses the weekly bulk XML file that carries the document,
+// from the date it was issued or published. Grants issue on Tuesdays and are
+// in PTGRXML as ipgYYMMDD.zip; pre-grant publications come out on Thursdays
+// and are in APPXML as ipaYYMMDD.zip. A date on another weekday is moved to
+// that week's Tuesday or Thursday (weeks run Monday to Sunday). A grant
+// issued 2025-09-23 gives "PTGRXML", "ipg250923.zip". The file may still not
+// exist - a reissue in a special issue, say - so treat the name as a guess.
+func (pn *PatentNumber) BulkFileName(date time.Time) (product, fileName string, err error) {
+	if date.IsZero() {
+		return "", "", fmt.Errorf("date is required")
+	}
+	var prefix string
+	var weekday time.Weekday
+	switch pn.Type {
+	case PatentNumberTypeGrant:
+		product, prefix, weekday = "PTGRXML", "ipg", time.Tuesday
+	case PatentNumberTypePublication:
+		product, prefix, weekday = "APPXML", "ipa", time.Thursday
+	default:
+		return "", "", fmt.Errorf("%s is not a grant or publication number", pn.Original)
+	}
+	// Days since Monday, so Sunday (0) ends the week rather than starting it.
+	sinceMonday := (int(date.Weekday()) + 6) % 7
+	day := date.AddDate(0, 0, int(weekday)-1-sinceMonday)
+	return product, prefix + day.Format("060102") + ".zip", nil
+}
+
+// DocumentStage is where a patent document sits in prosecution: an
+// unpublished application, a pre-grant publication, or an issued grant.
+type DocumentStage int
+
+// Document stage values.
+const (
+	DocumentStageUnknown DocumentStage = iota
+	DocumentStageApplication
+	DocumentStagePublication
+	DocumentStageGrant
+)
+
+// String returns the stage's display name, e.g. "Publication".
+func (s DocumentStage) String() string {
+	switch s {
+	case DocumentStageApplication:
+		return "Application"
+	case DocumentStagePublication:
+		return "Publication"
+	case DocumentStageGrant:
+		return "Grant"
+	default:
+		return "Unknown"
+	}
+}
+
+// KindCodeStage returns the stage a USPTO kind code denotes: A1, A2, A9 and
+// P1 (plant) are pre-grant publications; B1, B2, S (design), E (reissue),
+// P2/P3 (plant) and H (SIR) are grants. Unrecognised codes are Unknown.
+func KindCodeStage(kind string) DocumentStage {
+	kind = strings.ToUpper(strings.TrimSpace(kind))
+	switch {
+	case kind == "":
+		return DocumentStageUnknown
+	case kind == "P1", kind[0] == 'A':
+		return DocumentStagePublication
+	case kind[0] == 'B', kind[0] == 'S', kind[0] == 'E', kind[0] == 'P', kind[0] == 'H':
+		return DocumentStageGrant
+	default:
+		return DocumentStageUnknown
+	}
+}
+
+// DocumentLabel renders a stage and kind code for display, e.g.
+// "B2 Grant", "A1 Publication", or "Application" when there is no kind code.
+func DocumentLabel(stage DocumentStage, kind string) string {
+	kind = strings.ToUpper(strings.TrimSpace(kind))
+	if kind == "" {
+		return stage.String()
+	}
+	return kind + " " + stage.String()
+}
@@ -2,6 +2,7 @@ package odp
 
 import (
 	"testing"
+	"time"
 )
 
 func TestNormalizePatentNumber_Application(t *testing.T) {
@@ -81,6 +82,50 @@ func TestNormalizePatentNumber_Grant(t *testing.T) {
 	}
 }
 
+func TestNormalizePatentNumber_ApplicationCategory(t *testing.T) {
+	tests := []struct {
+		input     string
+		appNumber string
+		category  ApplicationCategory
+	}{
+		{"17/248,024", "17248024", ApplicationCategoryUtility},
+		{"29/123,456", "29123456", ApplicationCategoryDesign},
+		{"US 29/123456", "29123456", ApplicationCategoryDesign},
+		{"35/501,234", "35501234", ApplicationCategoryInternationalDesign},
+		{"63/012,345", "63012345", ApplicationCategoryProvisional},
+		{"90/012,345", "90012345", ApplicationCategoryExParteReexamination},
+		{"95/001,234", "95001234", ApplicationCategoryInterPartesReexamination},
+		{"96/000,123", "96000123", ApplicationCategorySupplementalExamination},
+		{"90012345", "90012345", ApplicationCategoryExParteReexamination},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			pn, err := NormalizePatentNumber(tt.input)
+			if err != nil {
+				t.Fatalf("Failed to normalize: %v", err)
+			}
+			if pn.Type != PatentNumberTypeApplication || pn.Ambiguous {
+				t.Errorf("Expected unambiguous application, got %v (ambiguous %v)", pn.Type, pn.Ambiguous)
+			}
+			if pn.ToApplicationNumber() != tt.appNumber {
+				t.Errorf("Expected ToApplicationNumber %s, got %s", tt.appNumber, pn.ToApplicationNumber())
+			}
+			if pn.Category != tt.category {
+				t.Errorf("Expected category %v, got %v", tt.category, pn.Category)
+			}
+			if want := tt.appNumber[:2] + "/" + tt.appNumber[2:5] + "," + tt.appNumber[5:]; pn.FormatAsApplication() != want {
+				t.Errorf("Expected FormatAsApplication %s, got %s", want, pn.FormatAsApplication())
+			}
+		})
+	}
+
+	grant, _ := NormalizePatentNumber("US 11,646,472 B2")
+	if grant.Category != ApplicationCategoryUnknown {
+		t.Errorf("grant Category = %v, want unknown", grant.Category)
+	}
+}
+
 func TestNormalizePatentNumber_Publication(t *testing.T) {
 	tests := []struct {
 		input      string
@@ -121,6 +166,9 @@ func TestNormalizePatentNumber_Invalid(t *testing.T) {
 		"patent123",
 		"123",
 		"1234567890123456", // too long
+		"D",
+		"D000",
+		"RE12345678", // too long for a reissue
 	}
 
 	for _, input := range tests {
@@ -150,6 +198,9 @@ func TestPatentNumber_FormatAsGrant(t *testing.T) {
 	}{
 		{"US 11,646,472 B2", "11,646,472"}, // Use formatted input with kind code
 		{"9123456", "9,123,456"},           // 7 digits is unambiguously a grant
+		{"USD0987654S", "D987,654"},        // Bulk XML padding is dropped
+		{"PP12345", "PP12,345"},
+		{"RE45678", "RE45,678"},
 	}
 
 	for _, tt := range tests {
@@ -164,6 +215,51 @@ func TestPatentNumber_FormatAsGrant(t *testing.T) {
 	}
 }
 
+func TestNormalizePatentNumber_PrefixedGrant(t *testing.T) {
+	tests := []struct {
+		input      string
+		normalized string
+		prefix     string
+		kind       string
+	}{
+		{"D123,456", "D123456", "D", ""},
+		{"D1012345", "D1012345", "D", ""},
+		{"US D0987654 S", "D987654", "D", "S"},
+		{"d987654s", "D987654", "D", "S"},
+		{"PP12,345", "PP12345", "PP", ""},
+		{"US PP 12,345 P3", "PP12345", "PP", "P3"},
+		{"RE45,678", "RE45678", "RE", ""},
+		{"RE049123E", "RE49123", "RE", "E"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			pn, err := NormalizePatentNumber(tt.input)
+			if err != nil {
+				t.Fatalf("Failed to normalize: %v", err)
+			}
+			if pn.Type != PatentNumberTypeGrant || pn.Ambiguous {
+				t.Errorf("Expected unambiguous grant, got %v (ambiguous %v)", pn.Type, pn.Ambiguous)
+			}
+			if pn.Normalized != tt.normalized || pn.Prefix != tt.prefix || pn.KindCode != tt.kind {
+				t.Errorf("Got normalized %q, prefix %q, kind %q; want %q, %q, %q",
+					pn.Normalized, pn.Prefix, pn.KindCode, tt.normalized, tt.prefix, tt.kind)
+			}
+
+			// FormatAsGrant output normalizes back to the same number.
+			again, err := NormalizePatentNumber(pn.FormatAsGrant())
+			if err != nil || again.Normalized != pn.Normalized || again.Prefix != pn.Prefix {
+				t.Errorf("FormatAsGrant() = %q does not round-trip: %+v, %v", pn.FormatAsGrant(), again, err)
+			}
+		})
+	}
+
+	utility, _ := NormalizePatentNumber("US 11,646,472 B2")
+	if utility.Prefix != "" {
+		t.Errorf("utility grant Prefix = %q, want empty", utility.Prefix)
+	}
+}
+
 func TestPatentNumber_FormatAsPublication(t *testing.T) {
 	pn, _ := NormalizePatentNumber("20250087686")
 	formatted := pn.FormatAsPublication()
@@ -299,6 +395,45 @@ func TestNormalizePatentNumber_PCT(t *testing.T) {
 	}
 }
 
+func TestNormalizePatentNumber_PCTComponents(t *testing.T) {
+	tests := []struct {
+		input      string
+		normalized string
+		want       PCTNumber
+	}{
+		{"PCT/US2023/017788", "PCTUS2023017788", PCTNumber{Office: "US", Year: 2023, Serial: "017788"}},
+		{"PCTUS2025058371", "PCTUS2025058371", PCTNumber{Office: "US", Year: 2025, Serial: "058371"}},
+		{"PCT/EP2019/012345", "PCTEP2019012345", PCTNumber{Office: "EP", Year: 2019, Serial: "012345"}},
+		{"PCTUS0719317", "PCTUS0719317", PCTNumber{Office: "US", Year: 2007, Serial: "19317"}},
+		{"PCT/US99/12345", "PCTUS9912345", PCTNumber{Office: "US", Year: 1999, Serial: "12345"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			pn, err := NormalizePatentNumber(tt.input)
+			if err != nil {
+				t.Fatalf("Failed to normalize: %v", err)
+			}
+			if pn.Type != PatentNumberTypePCT || pn.Normalized != tt.normalized {
+				t.Errorf("Got %v %s, want pct %s", pn.Type, pn.Normalized, tt.normalized)
+			}
+			if pn.PCT == nil || *pn.PCT != tt.want {
+				t.Errorf("PCT = %+v, want %+v", pn.PCT, tt.want)
+			}
+			if pn.Country != tt.want.Office {
+				t.Errorf("Country = %s, want %s", pn.Country, tt.want.Office)
+			}
+		})
+	}
+
+	if got := normalizePCTApplicationNumber("PCT/US2023/017788"); got != "PCTUS2023017788" {
+		t.Errorf("normalizePCTApplicationNumber = %s, want PCTUS2023017788", got)
+	}
+	if got := normalizePCTApplicationNumber("17248024"); got != "17248024" {
+		t.Errorf("normalizePCTApplicationNumber changed %s", got)
+	}
+}
+
 func TestNormalizePatentNumber_PublicationKindCode(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -384,3 +519,42 @@ func BenchmarkNormalizePatentNumber(b *testing.B) {
 		}
 	}
 }
+
+func TestPatentNumber_BulkFileName(t *testing.T) {
+	day := func(s string) time.Time {
+		d, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	tests := []struct {
+		number, date  string
+		product, file string
+	}{
+		{"US 11,646,472 B2", "2025-09-23", "PTGRXML", "ipg250923.zip"}, // a Tuesday
+		{"US 11,646,472 B2", "2025-09-26", "PTGRXML", "ipg250923.zip"}, // Friday of that week
+		{"US 11,646,472 B2", "2025-09-28", "PTGRXML", "ipg250923.zip"}, // Sunday ends the week
+		{"US20250087686A1", "2025-03-13", "APPXML", "ipa250313.zip"},   // a Thursday
+		{"US20250087686A1", "2025-03-10", "APPXML", "ipa250313.zip"},   // Monday of that week
+	}
+	for _, tt := range tests {
+		pn, err := NormalizePatentNumber(tt.number)
+		if err != nil {
+			t.Fatalf("NormalizePatentNumber(%q): %v", tt.number, err)
+		}
+		product, file, err := pn.BulkFileName(day(tt.date))
+		if err != nil || product != tt.product || file != tt.file {
+			t.Errorf("BulkFileName(%s, %s) = %q, %q, %v; want %q, %q", tt.number, tt.date, product, file, err, tt.product, tt.file)
+		}
+	}
+
+	app, _ := NormalizePatentNumber("17/248,024")
+	if _, _, err := app.BulkFileName(day("2025-09-23")); err == nil {
+		t.Error("application number: expected an error")
+	}
+	grant, _ := NormalizePatentNumber("US 11,646,472 B2")
+	if _, _, err := grant.BulkFileName(time.Time{}); err == nil {
+		t.Error("zero date: expected an error")
+	}
+}
@@ -0,0 +1,136 @@
+package odp
+
+import "strings"
+
+// QueryBuilder assembles a search query string for SearchPatents,
+// SearchPetitions and the other search methods, quoting values so that
+// spaces, colons and other query syntax in them cannot break the query:
+//
+//	q := odp.NewQuery().
+//		Field("applicationMetaData.patentNumber").Equals("11646472").
+//		Or().
+//		Field("applicationMetaData.filingDate").Range("2020-01-01", "2021-12-31").
+//		String()
+//	// applicationMetaData.patentNumber:11646472 OR applicationMetaData.filingDate:[2020-01-01 TO 2021-12-31]
+//
+// Terms written without an operator between them are joined with AND.
+// Not negates the next term, and Group nests another builder in
+// parentheses. Operators with no term after them are dropped. The zero
+// value is an empty query, ready to use.
+type QueryBuilder struct {
+	parts   []string
+	op      string // operator waiting for the next term
+	negated bool   // the next term is negated
+}
+
+// NewQuery returns an empty QueryBuilder.
+func NewQuery() *QueryBuilder {
+	return &QueryBuilder{}
+}
+
+// QueryField is a field whose condition has not been given yet, returned by
+// QueryBuilder.Field.
+type QueryField struct {
+	q    *QueryBuilder
+	name string
+}
+
+// Field starts a term on the named document field, e.g.
+// "applicationMetaData.inventionTitle".
+func (q *QueryBuilder) Field(name string) *QueryField {
+	return &QueryField{q: q, name: name}
+}
+
+// Equals matches documents whose field is v. Values that are not a single
+// plain word are quoted as a phrase.
+func (f *QueryField) Equals(v string) *QueryBuilder {
+	return f.q.term(f.name + ":" + queryValue(v))
+}
+
+// Range matches documents whose field lies between from and to, inclusive.
+// An empty bound is open.
+func (f *QueryField) Range(from, to string) *QueryBuilder {
+	return f.q.term(f.name + ":[" + rangeBound(from) + " TO " + rangeBound(to) + "]")
+}
+
+// Exists matches documents that have any value for the field.
+func (f *QueryField) Exists() *QueryBuilder {
+	return f.q.term(f.name + ":*")
+}
+
+// Match adds free text searched across all fields, quoted as a phrase when
+// it is more than a single plain word.
+func (q *QueryBuilder) Match(text string) *QueryBuilder {
+	return q.term(queryValue(text))
+}
+
+// Group adds sub, in parentheses, as one term. An empty sub adds nothing.
+func (q *QueryBuilder) Group(sub *QueryBuilder) *QueryBuilder {
+	s := sub.String()
+	if s == "" {
+		return q
+	}
+	return q.term("(" + s + ")")
+}
+
+// And joins the previous and next terms with AND.
+func (q *QueryBuilder) And() *QueryBuilder {
+	q.op = "AND"
+	return q
+}
+
+// Or joins the previous and next terms with OR.
+func (q *QueryBuilder) Or() *QueryBuilder {
+	q.op = "OR"
+	return q
+}
+
+// Not negates the next term.
+func (q *QueryBuilder) Not() *QueryBuilder {
+	q.negated = !q.negated
+	return q
+}
+
+// String returns the query. An empty builder returns "", which the search
+// methods treat as matching everything.
+func (q *QueryBuilder) String() string {
+	return strings.Join(q.parts, " ")
+}
+
+func (q *QueryBuilder) term(t string) *QueryBuilder {
+	if q.negated {
+		t = "NOT " + t
+		q.negated = false
+	}
+	if len(q.parts) > 0 {
+		op := q.op
+		if op == "" {
+			op = "AND"
+		}
+		q.parts = append(q.parts, op)
+	}
+	q.op = ""
+	q.parts = append(q.parts, t)
+	return q
+}
+
+// querySpecialChars are the characters with a meaning in query syntax.
+const querySpecialChars = " \t\n+-&|!(){}[]^\"~*?:\\/"
+
+// queryValue returns v unchanged when it is a single plain word, and quoted
+// as a phrase otherwise. Hyphenated dates such as 2021-03-15 stay unquoted:
+// a hyphen only has a meaning at the start of a word.
+func queryValue(v string) string {
+	if v == "" || v[0] == '-' || strings.ContainsAny(strings.ReplaceAll(v, "-", ""), querySpecialChars) {
+		return quoteQueryValue(v)
+	}
+	return v
+}
+
+// rangeBound formats one end of a range, "*" when open.
+func rangeBound(v string) string {
+	if v == "" {
+		return "*"
+	}
+	return queryValue(v)
+}
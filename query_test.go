@@ -0,0 +1,72 @@
+package odp
+
+import "testing"
+
+func TestQueryBuilder(t *testing.T) {
+	tests := []struct {
+		name string
+		q    *QueryBuilder
+		want string
+	}{
+		{
+			name: "empty",
+			q:    NewQuery(),
+			want: "",
+		},
+		{
+			name: "field and range",
+			q: NewQuery().
+				Field("applicationMetaData.patentNumber").Equals("11646472").
+				And().
+				Field("applicationMetaData.filingDate").Range("2020-01-01", "2021-12-31"),
+			want: "applicationMetaData.patentNumber:11646472 AND applicationMetaData.filingDate:[2020-01-01 TO 2021-12-31]",
+		},
+		{
+			name: "implicit AND and open range",
+			q: NewQuery().
+				Field("applicationMetaData.applicationTypeCode").Equals("UTL").
+				Field("applicationMetaData.grantDate").Range("2024-01-01", ""),
+			want: "applicationMetaData.applicationTypeCode:UTL AND applicationMetaData.grantDate:[2024-01-01 TO *]",
+		},
+		{
+			name: "phrase quoting",
+			q:    NewQuery().Field("applicationMetaData.inventionTitle").Equals(`solid "state" battery`),
+			want: `applicationMetaData.inventionTitle:"solid \"state\" battery"`,
+		},
+		{
+			name: "syntax characters are quoted",
+			q:    NewQuery().Field("applicationMetaData.firstApplicantName").Equals("AT&T (NY)"),
+			want: `applicationMetaData.firstApplicantName:"AT&T (NY)"`,
+		},
+		{
+			name: "leading hyphen is quoted",
+			q:    NewQuery().Match("-x"),
+			want: `"-x"`,
+		},
+		{
+			name: "or, not and group",
+			q: NewQuery().
+				Match("battery").
+				Not().Field("applicationMetaData.applicationStatusCode").Equals("161").
+				And().
+				Group(NewQuery().
+					Field("applicationMetaData.groupArtUnitNumber").Equals("1729").
+					Or().
+					Field("applicationMetaData.groupArtUnitNumber").Equals("1727")),
+			want: "battery AND NOT applicationMetaData.applicationStatusCode:161 AND " +
+				"(applicationMetaData.groupArtUnitNumber:1729 OR applicationMetaData.groupArtUnitNumber:1727)",
+		},
+		{
+			name: "dangling operators and empty group",
+			q:    NewQuery().Or().Field("applicationMetaData.docketNumber").Exists().Group(NewQuery()).And(),
+			want: "applicationMetaData.docketNumber:*",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.q.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package odp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_SpacesRequests(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":0,"patentFileWrapperDataBag":[]}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	cfg.RequestsPerSecond = 20
+	cfg.Burst = 2
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	// Burst 2 goes out at once; the remaining 4 are spaced 50ms apart.
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if _, err := client.SearchPatents(context.Background(), "x", 0, 1); err != nil {
+			t.Fatalf("SearchPatents #%d: %v", i, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("6 requests at 20/s with burst 2 took %v, want >= ~200ms", elapsed)
+	}
+	if got := hits.Load(); got != 6 {
+		t.Errorf("server hits = %d, want 6", got)
+	}
+}
+
+func TestRateLimiter_WaitHonorsContext(t *testing.T) {
+	l := newRateLimiter(1, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("first Wait: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("cancelled Wait took %v, want ~20ms", elapsed)
+	}
+}
+
+func TestNewClient_RejectsNegativeRequestsPerSecond(t *testing.T) {
+	if _, err := NewClient(&Config{BaseURL: "http://localhost", RequestsPerSecond: -1}); err == nil {
+		t.Error("NewClient accepted a negative RequestsPerSecond")
+	}
+}
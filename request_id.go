@@ -0,0 +1,87 @@
+package odp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+// RequestIDs collects the requestIdentifier of each successful API response
+// received with a context from WithRequestIDs, for correlating your logs
+// with USPTO's. A failed request's identifier is on its *APIError instead.
+//
+//	var ids odp.RequestIDs
+//	meta, err := client.GetPatentMetaData(odp.WithRequestIDs(ctx, &ids), "17248024")
+//	log.Printf("requestIdentifier=%s", ids.Last())
+//
+// A call that makes several requests (resolving a grant number, then
+// fetching the application) records one identifier per response that
+// carried one. Responses without one, such as file downloads and TSDR, add
+// nothing. It is safe for concurrent use.
+type RequestIDs struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+// Last returns the most recently recorded identifier, or "" if none.
+func (r *RequestIDs) Last() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.ids) == 0 {
+		return ""
+	}
+	return r.ids[len(r.ids)-1]
+}
+
+// All returns every recorded identifier, oldest first.
+func (r *RequestIDs) All() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.ids...)
+}
+
+func (r *RequestIDs) add(id string) {
+	r.mu.Lock()
+	r.ids = append(r.ids, id)
+	r.mu.Unlock()
+}
+
+type requestIDsKey struct{}
+
+// WithRequestIDs returns a context that records into r the requestIdentifier
+// of every successful API response received with it.
+func WithRequestIDs(ctx context.Context, r *RequestIDs) context.Context {
+	return context.WithValue(ctx, requestIDsKey{}, r)
+}
+
+func requestIDsFrom(ctx context.Context) *RequestIDs {
+	r, _ := ctx.Value(requestIDsKey{}).(*RequestIDs)
+	return r
+}
+
+// recordRequestID adds the requestIdentifier in a success body to the
+// RequestIDs attached to the request's context, if any. The body is only
+// decoded when one is attached.
+func recordRequestID(resp *http.Response, body []byte) {
+	if resp == nil || resp.Request == nil || len(body) == 0 {
+		return
+	}
+	ctx := resp.Request.Context()
+	if requestIDsFrom(ctx) == nil {
+		return
+	}
+	var v struct {
+		RequestIdentifier string `json:"requestIdentifier"`
+	}
+	if json.Unmarshal(body, &v) == nil {
+		addRequestID(ctx, v.RequestIdentifier)
+	}
+}
+
+// addRequestID adds id to the RequestIDs attached to ctx, if any.
+func addRequestID(ctx context.Context, id string) {
+	if ids := requestIDsFrom(ctx); ids != nil && id != "" {
+		ids.add(id)
+	}
+}
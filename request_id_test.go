@@ -0,0 +1,68 @@
+package odp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/patent-dev/uspto-odp/generated"
+)
+
+func TestWithRequestIDs(t *testing.T) {
+	var n atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(r.URL.Path, "/api/v1/patent/applications/19999999") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":404,"requestIdentifier":"req-404"}`))
+			return
+		}
+		fmt.Fprintf(w, `{"count":0,"patentFileWrapperDataBag":[],"requestIdentifier":"req-%d"}`, n.Add(1))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	var ids RequestIDs
+	ctx := WithRequestIDs(context.Background(), &ids)
+	if _, err := client.SearchPatents(ctx, "x", 0, 1); err != nil {
+		t.Fatalf("SearchPatents: %v", err)
+	}
+	if got := ids.Last(); got != "req-1" {
+		t.Errorf("Last() = %q, want req-1", got)
+	}
+
+	err = client.SearchPatentsStreamDecode(ctx, generated.PatentSearchRequest{}, func(*PatentFileWrapper) error { return nil })
+	if err != nil {
+		t.Fatalf("SearchPatentsStreamDecode: %v", err)
+	}
+	if got := ids.Last(); got != "req-2" {
+		t.Errorf("Last() after stream decode = %q, want req-2", got)
+	}
+
+	// A failure carries its identifier on the APIError, not the recorder.
+	if _, err := client.GetPatentMetaData(ctx, "19999999"); err == nil {
+		t.Fatal("expected a 404")
+	}
+	if got := ids.All(); len(got) != 2 || got[0] != "req-1" || got[1] != "req-2" {
+		t.Errorf("All() = %v, want [req-1 req-2]", got)
+	}
+
+	// Without a recorder nothing is collected.
+	if _, err := client.SearchPatents(context.Background(), "x", 0, 1); err != nil {
+		t.Fatalf("SearchPatents: %v", err)
+	}
+	if got := len(ids.All()); got != 2 {
+		t.Errorf("recorded %d ids, want 2", got)
+	}
+}
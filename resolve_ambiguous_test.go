@@ -158,6 +158,38 @@ func TestResolvePatentNumber_Ambiguous_ReportsBothCandidates(t *testing.T) {
 	}
 }
 
+func TestResolvePatentNumber_Trace(t *testing.T) {
+	client := newAmbiguityClient(t, ambiguityMock{
+		grantApp:   "17248024",
+		grantTitle: "MAKING LITHIUM METAL - SEAWATER BATTERY CELLS HAVING PROTECTED LITHIUM ELECTRODES",
+	})
+
+	var trace ResolutionTrace
+	app, err := client.ResolvePatentNumber(WithResolutionTrace(context.Background(), &trace), "US 11,646,472 B2")
+	if err != nil {
+		t.Fatalf("ResolvePatentNumber: %v", err)
+	}
+	if app != "17248024" || trace.ApplicationNumber != app {
+		t.Errorf("resolved %q, trace.ApplicationNumber = %q; want 17248024", app, trace.ApplicationNumber)
+	}
+	if trace.Type != PatentNumberTypeGrant || trace.Normalized != "11646472" || trace.Ambiguous {
+		t.Errorf("trace detection = %s %q ambiguous=%v, want unambiguous grant 11646472", trace.Type, trace.Normalized, trace.Ambiguous)
+	}
+	want := ResolutionStep{Interpretation: PatentNumberTypeGrant, Query: "applicationMetaData.patentNumber:11646472", ApplicationNumber: "17248024"}
+	if len(trace.Steps) != 1 || trace.Steps[0] != want {
+		t.Errorf("steps = %+v, want [%+v]", trace.Steps, want)
+	}
+
+	// A bare number probes both interpretations; both show up in the trace.
+	_, err = client.ResolvePatentNumber(WithResolutionTrace(context.Background(), &trace), "US11646472")
+	if err != nil {
+		t.Fatalf("ResolvePatentNumber(bare): %v", err)
+	}
+	if !trace.Ambiguous || len(trace.Steps) != 2 || trace.Steps[1].Interpretation != PatentNumberTypeApplication || trace.Steps[1].ApplicationNumber != "" {
+		t.Errorf("bare-number trace = %s", &trace)
+	}
+}
+
 func TestResolvePatentNumber_BareGrantOnly_AutoResolves(t *testing.T) {
 	// US10000000: grant exists, no application 10000000 -> auto-resolve as grant.
 	client := newAmbiguityClient(t, ambiguityMock{
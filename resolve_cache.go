@@ -0,0 +1,116 @@
+package odp
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// resolveCache remembers successful patent number resolutions for
+// Config.ResolveCacheTTL. A nil *resolveCache caches nothing.
+type resolveCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]resolveCacheEntry
+}
+
+type resolveCacheEntry struct {
+	applicationNumber string
+	expires           time.Time
+}
+
+// maxResolveCacheEntries bounds the cache so a long batch job resolving
+// many distinct numbers does not grow it without limit.
+const maxResolveCacheEntries = 10000
+
+// newResolveCache returns a cache for ttl, or nil unless ttl is positive.
+func newResolveCache(ttl time.Duration) *resolveCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &resolveCache{ttl: ttl, entries: make(map[string]resolveCacheEntry)}
+}
+
+func (rc *resolveCache) get(key string) (string, bool) {
+	if rc == nil {
+		return "", false
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	e, ok := rc.entries[key]
+	if !ok {
+		return "", false
+	}
+	if time.Now().After(e.expires) {
+		delete(rc.entries, key)
+		return "", false
+	}
+	return e.applicationNumber, true
+}
+
+func (rc *resolveCache) put(key, applicationNumber string) {
+	if rc == nil {
+		return
+	}
+	now := time.Now()
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if _, ok := rc.entries[key]; !ok && len(rc.entries) >= maxResolveCacheEntries {
+		rc.evict(now)
+	}
+	rc.entries[key] = resolveCacheEntry{applicationNumber: applicationNumber, expires: now.Add(rc.ttl)}
+}
+
+// evict makes room for one entry: it drops every expired entry and, if
+// none had expired, the one closest to expiring. rc.mu must be held.
+func (rc *resolveCache) evict(now time.Time) {
+	oldestKey, oldest := "", time.Time{}
+	for k, e := range rc.entries {
+		if now.After(e.expires) {
+			delete(rc.entries, k)
+			continue
+		}
+		if oldestKey == "" || e.expires.Before(oldest) {
+			oldestKey, oldest = k, e.expires
+		}
+	}
+	if len(rc.entries) >= maxResolveCacheEntries {
+		delete(rc.entries, oldestKey)
+	}
+}
+
+// resolveCacheKey identifies a resolution by how the number was read, so
+// "US 11,646,472 B2" and "11,646,472" share an entry while the grant and
+// application readings of the same digits do not. Publications default to
+// kind A1, as resolvePublicationToApplicationNumber does.
+func resolveCacheKey(pn *PatentNumber, ambiguous bool) string {
+	switch {
+	case ambiguous:
+		return "ambiguous:" + pn.Normalized
+	case pn.Type == PatentNumberTypePublication:
+		kind := pn.KindCode
+		if kind == "" {
+			kind = "A1"
+		}
+		return "publication:" + pn.Normalized + ":" + kind
+	default:
+		return pn.Type.String() + ":" + pn.Normalized
+	}
+}
+
+// cachedResolve returns the cached resolution for key, or calls resolve and
+// caches what it returns unless it fails.
+func (c *Client) cachedResolve(ctx context.Context, key string, resolve func() (string, error)) (string, error) {
+	if appNumber, ok := c.resolved.get(key); ok {
+		if t := resolutionTraceFrom(ctx); t != nil {
+			t.Cached = true
+		}
+		return appNumber, nil
+	}
+	appNumber, err := resolve()
+	if err == nil {
+		c.resolved.put(key, appNumber)
+	}
+	return appNumber, err
+}
@@ -0,0 +1,154 @@
+package odp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newResolveCountingClient returns a client whose grant and publication
+// searches resolve to 17248024, and a counter of those searches.
+func newResolveCountingClient(t *testing.T, ttl time.Duration) (*Client, *atomic.Int32) {
+	t.Helper()
+	var searches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/patent/applications/search" {
+			searches.Add(1)
+		}
+		writeWrapperBag(w, "17248024", "Electrode protection")
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = srv.URL
+	cfg.APIKey = "test"
+	cfg.ResolveCacheTTL = ttl
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client, &searches
+}
+
+func TestResolveCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("grant resolved once", func(t *testing.T) {
+		client, searches := newResolveCountingClient(t, time.Hour)
+		// Different spellings of the same grant share the cache entry.
+		for _, n := range []string{"US 11,646,472 B2", "11,646,472", "US11646472B2"} {
+			app, err := client.ResolvePatentNumber(ctx, n)
+			if err != nil || app != "17248024" {
+				t.Fatalf("ResolvePatentNumber(%q) = %q, %v", n, app, err)
+			}
+		}
+		if _, err := client.GetPatent(ctx, "US 11,646,472 B2"); err != nil {
+			t.Fatalf("GetPatent: %v", err)
+		}
+		if n := searches.Load(); n != 1 {
+			t.Errorf("searches = %d, want 1", n)
+		}
+
+		var trace ResolutionTrace
+		if _, err := client.ResolvePatentNumber(WithResolutionTrace(ctx, &trace), "11,646,472"); err != nil {
+			t.Fatal(err)
+		}
+		if !trace.Cached || len(trace.Steps) != 0 || trace.ApplicationNumber != "17248024" {
+			t.Errorf("trace = %+v, want a cached result without steps", trace)
+		}
+	})
+
+	t.Run("publication kinds kept apart", func(t *testing.T) {
+		client, searches := newResolveCountingClient(t, time.Hour)
+		for _, n := range []string{"US20250087686A1", "20250087686", "US20250087686A2"} {
+			if _, err := client.ResolvePatentNumber(ctx, n); err != nil {
+				t.Fatalf("ResolvePatentNumber(%q): %v", n, err)
+			}
+		}
+		if n := searches.Load(); n != 2 {
+			t.Errorf("searches = %d, want 2 (A1 cached, A2 looked up)", n)
+		}
+	})
+
+	t.Run("entries expire after TTL", func(t *testing.T) {
+		client, searches := newResolveCountingClient(t, 10*time.Millisecond)
+		if _, err := client.ResolvePatentNumber(ctx, "11,646,472"); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(20 * time.Millisecond)
+		if _, err := client.ResolvePatentNumber(ctx, "11,646,472"); err != nil {
+			t.Fatal(err)
+		}
+		if n := searches.Load(); n != 2 {
+			t.Errorf("searches = %d, want 2 after expiry", n)
+		}
+	})
+
+	for _, ttl := range []time.Duration{0, -1} {
+		t.Run(fmt.Sprintf("TTL %v disables", ttl), func(t *testing.T) {
+			client, searches := newResolveCountingClient(t, ttl)
+			for range 2 {
+				if _, err := client.ResolvePatentNumber(ctx, "11,646,472"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if n := searches.Load(); n != 2 {
+				t.Errorf("searches = %d, want 2 with the cache off", n)
+			}
+		})
+	}
+
+	t.Run("concurrent", func(t *testing.T) {
+		client, _ := newResolveCountingClient(t, time.Hour)
+		var wg sync.WaitGroup
+		for range 16 {
+			wg.Go(func() {
+				if app, err := client.ResolvePatentNumber(ctx, "11,646,472"); err != nil || app != "17248024" {
+					t.Errorf("ResolvePatentNumber = %q, %v", app, err)
+				}
+			})
+		}
+		wg.Wait()
+	})
+}
+
+func TestResolveCacheBounded(t *testing.T) {
+	rc := newResolveCache(time.Hour)
+	for i := range maxResolveCacheEntries + 5 {
+		rc.put(fmt.Sprint(i), "17248024")
+	}
+	if n := len(rc.entries); n != maxResolveCacheEntries {
+		t.Errorf("entries = %d, want the cap %d", n, maxResolveCacheEntries)
+	}
+	if _, ok := rc.get("0"); ok {
+		t.Error("the oldest entry should have been evicted")
+	}
+	if _, ok := rc.get(fmt.Sprint(maxResolveCacheEntries + 4)); !ok {
+		t.Error("the newest entry should be cached")
+	}
+
+	// Expired entries are swept before anything live is dropped.
+	rc = newResolveCache(time.Hour)
+	for i := range maxResolveCacheEntries {
+		rc.put(fmt.Sprint(i), "17248024")
+	}
+	for k, e := range rc.entries {
+		if k != "live" && k != "0" {
+			e.expires = time.Now().Add(-time.Second)
+			rc.entries[k] = e
+		}
+	}
+	rc.put("new", "17248024")
+	if n := len(rc.entries); n != 2 {
+		t.Errorf("entries after sweep = %d, want 2", n)
+	}
+	if _, ok := rc.get("0"); !ok {
+		t.Error("a live entry was evicted although expired ones were available")
+	}
+}
@@ -0,0 +1,102 @@
+package odp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ResolutionStep is one lookup made while resolving a patent number.
+type ResolutionStep struct {
+	// Interpretation is the number type this step tried.
+	Interpretation PatentNumberType
+	// Query is the search query sent, or "" for a direct application lookup
+	// or a number used as-is.
+	Query string
+	// ApplicationNumber is what the step resolved to; empty when it found
+	// nothing.
+	ApplicationNumber string
+}
+
+// ResolutionTrace records how a patent number was turned into an
+// application number: what the input was detected as and each search or
+// lookup made along the way. Attach one to a context with
+// WithResolutionTrace; ResolvePatentNumber, and every method that accepts
+// any patent number format (GetPatent, GetPatentXML, ...), fill it in.
+type ResolutionTrace struct {
+	Input      string
+	Normalized string
+	Type       PatentNumberType
+	Ambiguous  bool
+	// Cached is true when the result came from the Client's resolution
+	// cache (see Config.ResolveCacheTTL) rather than a lookup.
+	Cached bool
+	Steps  []ResolutionStep
+	// ApplicationNumber is the final result, empty if resolution failed.
+	ApplicationNumber string
+}
+
+// String renders the trace on one line for logging.
+func (t *ResolutionTrace) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%q -> %s %s", t.Input, t.Type, t.Normalized)
+	if t.Ambiguous {
+		b.WriteString(" (ambiguous)")
+	}
+	for _, s := range t.Steps {
+		q := s.Query
+		if q == "" {
+			q = "direct"
+		}
+		found := s.ApplicationNumber
+		if found == "" {
+			found = "none"
+		}
+		fmt.Fprintf(&b, "; %s [%s] => %s", s.Interpretation, q, found)
+	}
+	if t.ApplicationNumber != "" {
+		fmt.Fprintf(&b, "; resolved %s", t.ApplicationNumber)
+	}
+	if t.Cached {
+		b.WriteString(" (cached)")
+	}
+	return b.String()
+}
+
+type resolutionTraceKey struct{}
+
+// WithResolutionTrace returns a context that records patent number
+// resolution into t. A trace describes one resolution; when a call resolves
+// several numbers (AreRelated), t holds the last; GetPatents leaves it
+// untouched. t is not safe for concurrent use, so give each goroutine its
+// own.
+func WithResolutionTrace(ctx context.Context, t *ResolutionTrace) context.Context {
+	return context.WithValue(ctx, resolutionTraceKey{}, t)
+}
+
+func resolutionTraceFrom(ctx context.Context) *ResolutionTrace {
+	t, _ := ctx.Value(resolutionTraceKey{}).(*ResolutionTrace)
+	return t
+}
+
+// traceStart resets the context's trace, if any, for a new resolution of pn.
+func traceStart(ctx context.Context, input string, pn *PatentNumber) {
+	if t := resolutionTraceFrom(ctx); t != nil {
+		*t = ResolutionTrace{Input: input, Normalized: pn.Normalized, Type: pn.Type, Ambiguous: pn.Ambiguous}
+	}
+}
+
+// traceStep appends a lookup to the context's trace, if any.
+func traceStep(ctx context.Context, interpretation PatentNumberType, query, appNumber string) {
+	if t := resolutionTraceFrom(ctx); t != nil {
+		t.Steps = append(t.Steps, ResolutionStep{Interpretation: interpretation, Query: query, ApplicationNumber: appNumber})
+	}
+}
+
+// traceResult records the outcome of a resolution and passes it through.
+func traceResult(ctx context.Context, appNumber string, err error) (string, error) {
+	if t := resolutionTraceFrom(ctx); t != nil && err == nil {
+		t.ApplicationNumber = appNumber
+	}
+	return appNumber, err
+}
@@ -1,6 +1,18 @@
 package odp
 
-import "testing"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/patent-dev/uspto-odp/generated"
+)
 
 func TestBuildSearchFilters(t *testing.T) {
 	// Empty field or no values -> skipped; the rest pass through with copied values.
@@ -47,3 +59,474 @@ func TestBuildSearchRanges(t *testing.T) {
 		t.Errorf("range[1] = %+v, want From=nil To=2023-12-31", out[1])
 	}
 }
+
+func TestSearchPatentsByFilingDateRange(t *testing.T) {
+	var got generated.PatentSearchRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/patent/applications/search" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		got = generated.PatentSearchRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode request body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":0,"patentFileWrapperDataBag":[]}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	from := time.Date(2025, time.July, 1, 15, 4, 5, 0, time.UTC)
+	to := time.Date(2025, time.September, 30, 0, 0, 0, 0, time.UTC)
+	if _, err := client.SearchPatentsByFilingDateRange(context.Background(), from, to, 0, 25); err != nil {
+		t.Fatalf("SearchPatentsByFilingDateRange: %v", err)
+	}
+
+	if got.Q != nil {
+		t.Errorf("q = %q, want omitted for a range-only search", *got.Q)
+	}
+	if got.RangeFilters == nil || len(*got.RangeFilters) != 1 {
+		t.Fatalf("rangeFilters = %v, want exactly one", got.RangeFilters)
+	}
+	rf := (*got.RangeFilters)[0]
+	if rf.Field == nil || *rf.Field != "applicationMetaData.filingDate" {
+		t.Errorf("range field = %v, want applicationMetaData.filingDate", rf.Field)
+	}
+	if rf.ValueFrom == nil || *rf.ValueFrom != "2025-07-01" {
+		t.Errorf("valueFrom = %v, want 2025-07-01", rf.ValueFrom)
+	}
+	if rf.ValueTo == nil || *rf.ValueTo != "2025-09-30" {
+		t.Errorf("valueTo = %v, want 2025-09-30", rf.ValueTo)
+	}
+	if got.Pagination == nil || got.Pagination.Limit == nil || *got.Pagination.Limit != 25 {
+		t.Errorf("pagination = %+v, want limit 25", got.Pagination)
+	}
+
+	// Open upper bound: only valueFrom is sent.
+	if _, err := client.SearchPatentsByFilingDateRange(context.Background(), from, time.Time{}, 0, 25); err != nil {
+		t.Fatalf("open-ended range: %v", err)
+	}
+	if rf := (*got.RangeFilters)[0]; rf.ValueTo != nil {
+		t.Errorf("valueTo = %q, want omitted for an open upper bound", *rf.ValueTo)
+	}
+
+	// Invalid ranges are rejected before any request.
+	if _, err := client.SearchPatentsByFilingDateRange(context.Background(), to, from, 0, 25); err == nil {
+		t.Error("expected error for inverted range")
+	}
+	if _, err := client.SearchPatentsByFilingDateRange(context.Background(), time.Time{}, time.Time{}, 0, 25); err == nil {
+		t.Error("expected error for a range with no bounds")
+	}
+
+	// SearchPatents still sends an empty query as given.
+	if _, err := client.SearchPatents(context.Background(), "", 0, 25); err != nil {
+		t.Fatalf("SearchPatents: %v", err)
+	}
+	if got.Q == nil || *got.Q != "" {
+		t.Errorf("q = %v, want an empty query sent", got.Q)
+	}
+}
+
+func TestSearchPatentsFromCursor_Resume(t *testing.T) {
+	// Five records served in pages honouring the request's offset/limit.
+	apps := []string{"17000001", "17000002", "17000003", "17000004", "17000005"}
+	var offsets []int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req generated.PatentSearchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request body: %v", err)
+		}
+		off, lim := int(*req.Pagination.Offset), int(*req.Pagination.Limit)
+		offsets = append(offsets, int32(off))
+		end := min(off+lim, len(apps))
+		bag := make([]map[string]string, 0, lim)
+		for _, a := range apps[min(off, end):end] {
+			bag = append(bag, map[string]string{"applicationNumberText": a})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"count": len(apps), "patentFileWrapperDataBag": bag})
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	ctx := context.Background()
+
+	var seen []string
+	collect := func(resp *generated.PatentDataResponse) {
+		for _, w := range *resp.PatentFileWrapperDataBag {
+			seen = append(seen, *w.ApplicationNumberText)
+		}
+	}
+
+	cursor := NewSearchCursor("electrode", nil)
+	resp, err := client.SearchPatentsFromCursor(ctx, cursor, 2)
+	if err != nil {
+		t.Fatalf("first page: %v", err)
+	}
+	collect(resp)
+
+	// Simulate an interruption: persist the cursor and reload it.
+	saved, err := json.Marshal(cursor)
+	if err != nil {
+		t.Fatalf("marshal cursor: %v", err)
+	}
+	var resumed SearchCursor
+	if err := json.Unmarshal(saved, &resumed); err != nil {
+		t.Fatalf("unmarshal cursor: %v", err)
+	}
+	if resumed.Query != "electrode" || resumed.Offset != 2 || resumed.Done {
+		t.Fatalf("reloaded cursor = %+v, want query electrode at offset 2", resumed)
+	}
+
+	for !resumed.Done {
+		resp, err := client.SearchPatentsFromCursor(ctx, &resumed, 2)
+		if err != nil {
+			t.Fatalf("resumed page at %d: %v", resumed.Offset, err)
+		}
+		collect(resp)
+	}
+
+	if len(seen) != len(apps) {
+		t.Fatalf("crawl yielded %v, want each of %v exactly once", seen, apps)
+	}
+	for i := range apps {
+		if seen[i] != apps[i] {
+			t.Errorf("record %d = %s, want %s", i, seen[i], apps[i])
+		}
+	}
+	if want := []int32{0, 2, 4}; len(offsets) != len(want) || offsets[1] != 2 || offsets[2] != 4 {
+		t.Errorf("request offsets = %v, want %v", offsets, want)
+	}
+
+	// A finished cursor makes no further requests.
+	if _, err := client.SearchPatentsFromCursor(ctx, &resumed, 2); err != nil {
+		t.Fatalf("done cursor: %v", err)
+	}
+	if len(offsets) != 3 {
+		t.Errorf("done cursor issued a request; offsets = %v", offsets)
+	}
+}
+
+func TestSearchPatentsAdvanced_PassesRequestThrough(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/patent/applications/search" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode request body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":1,"patentFileWrapperDataBag":[{"applicationNumberText":"17248024"}]}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	desc := generated.SortOrderDesc
+	facets := []string{"applicationMetaData.applicationStatusCode"}
+	req := generated.PatentSearchRequest{
+		Q:          StringPtr("electrode"),
+		Facets:     &facets,
+		Sort:       &[]generated.Sort{{Field: StringPtr("applicationMetaData.filingDate"), Order: &desc}},
+		Pagination: &generated.Pagination{Offset: Int32Ptr(0), Limit: Int32Ptr(5)},
+	}
+	resp, err := client.SearchPatentsAdvanced(context.Background(), req)
+	if err != nil {
+		t.Fatalf("SearchPatentsAdvanced: %v", err)
+	}
+	if resp == nil || resp.Count == nil || *resp.Count != 1 {
+		t.Fatalf("response = %+v, want count 1", resp)
+	}
+
+	want, _ := json.Marshal(req)
+	var wantMap map[string]any
+	_ = json.Unmarshal(want, &wantMap)
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(wantMap)
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("request body = %s, want %s", gotJSON, wantJSON)
+	}
+}
+
+func TestSearchPatents_Options(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode request body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":0,"patentFileWrapperDataBag":[]}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	_, err = client.SearchPatents(context.Background(), "artificial intelligence", 0, 25,
+		WithSort("applicationMetaData.filingDate", "Desc"),
+		WithFields("applicationNumberText", "applicationMetaData.inventionTitle"),
+		WithFilter("applicationMetaData.applicationTypeLabelName", "Utility"))
+	if err != nil {
+		t.Fatalf("SearchPatents: %v", err)
+	}
+	gotJSON, _ := json.Marshal(map[string]any{"sort": body["sort"], "fields": body["fields"], "filters": body["filters"]})
+	wantJSON := `{"fields":["applicationNumberText","applicationMetaData.inventionTitle"],` +
+		`"filters":[{"name":"applicationMetaData.applicationTypeLabelName","value":["Utility"]}],` +
+		`"sort":[{"field":"applicationMetaData.filingDate","order":"Desc"}]}`
+	if string(gotJSON) != wantJSON {
+		t.Errorf("request = %s, want %s", gotJSON, wantJSON)
+	}
+
+	// Without options the request carries none of them.
+	body = nil
+	if _, err := client.SearchPatents(context.Background(), "x", 0, 1); err != nil {
+		t.Fatalf("SearchPatents: %v", err)
+	}
+	for _, k := range []string{"sort", "fields", "filters"} {
+		if v, ok := body[k]; ok {
+			t.Errorf("plain search sent %s = %v", k, v)
+		}
+	}
+}
+
+func TestCountPatentsAndPetitions(t *testing.T) {
+	var mu sync.Mutex
+	bodies := map[string]map[string]any{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode request body: %v", err)
+		}
+		mu.Lock()
+		bodies[r.URL.Path] = body
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/patent/applications/search":
+			_, _ = w.Write([]byte(`{"count":12345,"patentFileWrapperDataBag":[{"applicationNumberText":"17248024"}]}`))
+		case "/api/v1/petition/decisions/search":
+			_, _ = w.Write([]byte(`{"count":42,"petitionDecisionDataBag":[{}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	ctx := context.Background()
+
+	n, err := client.CountPatents(ctx, "applicationMetaData.inventionTitle:battery")
+	if err != nil || n != 12345 {
+		t.Errorf("CountPatents = %d, %v; want 12345, nil", n, err)
+	}
+	req := bodies["/api/v1/patent/applications/search"]
+	if fields, _ := req["fields"].([]any); len(fields) != 1 || fields[0] != "applicationNumberText" {
+		t.Errorf("patent search fields = %v, want [applicationNumberText]", req["fields"])
+	}
+	if pag, _ := req["pagination"].(map[string]any); pag["limit"] != float64(1) {
+		t.Errorf("patent search pagination = %v, want limit 1", req["pagination"])
+	}
+
+	n, err = client.CountPetitions(ctx, "decisionTypeCodeDescriptionText:DENIED")
+	if err != nil || n != 42 {
+		t.Errorf("CountPetitions = %d, %v; want 42, nil", n, err)
+	}
+	if pag, _ := bodies["/api/v1/petition/decisions/search"]["pagination"].(map[string]any); pag["limit"] != float64(1) {
+		t.Errorf("petition search pagination = %v, want limit 1", pag)
+	}
+}
+
+func TestSearchPatentsStreamDecode(t *testing.T) {
+	// The server sends two records, then holds the rest of the body until the
+	// callback has seen the second one. A client that buffered the whole
+	// response before decoding would never get there.
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":4,"patentFileWrapperDataBag":[` +
+			`{"applicationNumberText":"17000001"},` +
+			`{"applicationNumberText":"17000002","eventDataBag":[{"eventCode":"CTNF"}]},`))
+		w.(http.Flusher).Flush()
+		select {
+		case <-release:
+		case <-time.After(5 * time.Second):
+			t.Error("callback never saw the flushed records; response was not streamed")
+			return
+		}
+		_, _ = w.Write([]byte(`{"applicationNumberText":"17000003"},` +
+			`{"applicationNumberText":"17000004"}],"requestIdentifier":"abc"}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	var got []string
+	err = client.SearchPatentsStreamDecode(context.Background(), generated.PatentSearchRequest{Q: StringPtr("electrode")}, func(rec *PatentFileWrapper) error {
+		got = append(got, derefStr(rec.ApplicationNumberText))
+		if len(got) == 2 {
+			if rec.EventDataBag == nil || len(*rec.EventDataBag) != 1 {
+				t.Errorf("record 2 events = %v, want 1", rec.EventDataBag)
+			}
+			close(release)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SearchPatentsStreamDecode: %v", err)
+	}
+	want := []string{"17000001", "17000002", "17000003", "17000004"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("records = %v, want %v", got, want)
+	}
+}
+
+func TestSearchPatentsStreamDecode_CallbackErrorStops(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":3,"patentFileWrapperDataBag":[` +
+			`{"applicationNumberText":"1"},{"applicationNumberText":"2"},{"applicationNumberText":"3"}]}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = client.SearchPatentsStreamDecode(context.Background(), generated.PatentSearchRequest{}, func(*PatentFileWrapper) error {
+		calls++
+		if calls == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("err = %v, want the callback's error", err)
+	}
+	if calls != 2 {
+		t.Errorf("callback ran %d times, want 2", calls)
+	}
+}
+
+// TestSearchPatentsStreamDecode_StopsWithoutDraining checks that stopping
+// early closes the stream instead of reading the rest of it: the server
+// holds the remainder back until the client hangs up.
+func TestSearchPatentsStreamDecode_StopsWithoutDraining(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":2,"patentFileWrapperDataBag":[{"applicationNumberText":"1"},`))
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+		_, _ = w.Write([]byte(`{"applicationNumberText":"2"}]}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	stop := errors.New("stop")
+	start := time.Now()
+	err = client.SearchPatentsStreamDecode(context.Background(), generated.PatentSearchRequest{}, func(*PatentFileWrapper) error {
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("err = %v, want the callback's error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("returned after %v, want without waiting for the rest of the stream", elapsed)
+	}
+}
+
+func TestSearchPatentsGET(t *testing.T) {
+	var method, path string
+	var query map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, query = r.Method, r.URL.Path, r.URL.Query()
+		if r.ContentLength > 0 {
+			t.Errorf("GET search sent a %d-byte body", r.ContentLength)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":1,"patentFileWrapperDataBag":[{"applicationNumberText":"17248024"}]}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	resp, err := client.SearchPatentsGET(context.Background(), "electrode AND lithium", 20, 10)
+	if err != nil {
+		t.Fatalf("SearchPatentsGET: %v", err)
+	}
+	if resp == nil || resp.Count == nil || *resp.Count != 1 {
+		t.Fatalf("response = %+v, want count 1", resp)
+	}
+	if method != http.MethodGet || path != "/api/v1/patent/applications/search" {
+		t.Errorf("request = %s %s, want GET /api/v1/patent/applications/search", method, path)
+	}
+	for param, want := range map[string]string{"q": "electrode AND lithium", "offset": "20", "limit": "10"} {
+		if got := query[param]; len(got) != 1 || got[0] != want {
+			t.Errorf("query param %s = %v, want %q", param, got, want)
+		}
+	}
+
+	if _, err := client.SearchPatentsGET(context.Background(), "x", -1, 10); err == nil {
+		t.Error("SearchPatentsGET accepted a negative offset")
+	}
+}
@@ -0,0 +1,48 @@
+package odp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimeoutYieldsToContextDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Headers go out at once; the body is late, so the timeout must
+		// also cover reading it.
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		select {
+		case <-time.After(300 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		_, _ = w.Write([]byte(`{"count":0,"patentFileWrapperDataBag":[]}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	cfg.MaxRetries = 0
+	cfg.Timeout = 50 * time.Millisecond
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	_, err = client.SearchPatents(context.Background(), "x", 0, 1)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("without a deadline: err = %v, want Config.Timeout to expire", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := client.SearchPatents(ctx, "x", 0, 1); err != nil {
+		t.Errorf("with a longer context deadline: %v", err)
+	}
+}
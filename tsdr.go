@@ -46,14 +46,14 @@ func (c *Client) GetTrademarkStatus(ctx context.Context, serialNumber string) (*
 	}
 
 	var resp *tsdrgen.LoadXMLResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryLoop(ctx, func(ctx context.Context) error {
 		resp = nil
 		var err error
 		resp, err = c.tsdr.LoadXMLWithResponse(ctx, serialNumber)
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		if err := checkEmptyBody(resp.StatusCode(), resp.Body); err != nil {
@@ -85,7 +85,7 @@ func (c *Client) GetTrademarkStatusJSON(ctx context.Context, serialNumber string
 	}
 
 	var result TSDRStatusResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryLoop(ctx, func(ctx context.Context) error {
 		result = TSDRStatusResponse{}
 		resp, err := c.tsdr.LoadXML(ctx, serialNumber, tsdrJSONEditor)
 		if err != nil {
@@ -109,7 +109,7 @@ func (c *Client) GetTrademarkDocumentsXML(ctx context.Context, serialNumber stri
 	}
 
 	var result []byte
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryLoop(ctx, func(ctx context.Context) error {
 		resp, err := c.tsdr.GetCaseDocsInfoXml(ctx, serialNumber)
 		if err != nil {
 			return err
@@ -119,7 +119,7 @@ func (c *Client) GetTrademarkDocumentsXML(ctx context.Context, serialNumber stri
 		if err != nil {
 			return fmt.Errorf("reading response: %w", err)
 		}
-		if err := checkResponseStatus(resp.StatusCode, body, resp.Header); err != nil {
+		if err := checkResponseStatus(resp.StatusCode, body, resp); err != nil {
 			return err
 		}
 		if err := checkEmptyBody(resp.StatusCode, body); err != nil {
@@ -144,7 +144,7 @@ func (c *Client) GetTrademarkDocumentInfo(ctx context.Context, serialNumber, doc
 	}
 
 	var result []byte
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryLoop(ctx, func(ctx context.Context) error {
 		resp, err := c.tsdr.GetDocumentInfoXml(ctx, serialNumber, docID)
 		if err != nil {
 			return err
@@ -154,7 +154,7 @@ func (c *Client) GetTrademarkDocumentInfo(ctx context.Context, serialNumber, doc
 		if err != nil {
 			return fmt.Errorf("reading response: %w", err)
 		}
-		if err := checkResponseStatus(resp.StatusCode, body, resp.Header); err != nil {
+		if err := checkResponseStatus(resp.StatusCode, body, resp); err != nil {
 			return err
 		}
 		if err := checkEmptyBody(resp.StatusCode, body); err != nil {
@@ -184,7 +184,7 @@ func (c *Client) DownloadTrademarkDocument(ctx context.Context, serialNumber, do
 			// the Retry-After header (e.g. on 429), matching the other endpoints.
 			body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
 			drainClose(resp.Body)
-			return checkResponseStatus(resp.StatusCode, body, resp.Header)
+			return checkResponseStatus(resp.StatusCode, body, resp)
 		}
 		ct := resp.Header.Get("Content-Type")
 		if ct != "" && !strings.HasPrefix(ct, "application/pdf") {
@@ -197,7 +197,7 @@ func (c *Client) DownloadTrademarkDocument(ctx context.Context, serialNumber, do
 	if c.config.MaxRetries > 0 {
 		// Buffer the download to prevent partial writes on retry
 		var buf bytes.Buffer
-		err := c.retryableRequest(ctx, func() error {
+		err := c.retryLoop(ctx, func(ctx context.Context) error {
 			buf.Reset()
 			resp, err := c.tsdr.GetDocumentContentPdf(ctx, serialNumber, docID)
 			if err != nil {
@@ -218,16 +218,18 @@ func (c *Client) DownloadTrademarkDocument(ctx context.Context, serialNumber, do
 	}
 
 	// No retries: stream directly to writer
-	resp, err := c.tsdr.GetDocumentContentPdf(ctx, serialNumber, docID)
-	if err != nil {
-		return err
-	}
-	defer drainClose(resp.Body)
-	if err := checkPDFResponse(resp); err != nil {
+	return c.observeCall(ctx, func(ctx context.Context) error {
+		resp, err := c.tsdr.GetDocumentContentPdf(ctx, serialNumber, docID)
+		if err != nil {
+			return err
+		}
+		defer drainClose(resp.Body)
+		if err := checkPDFResponse(resp); err != nil {
+			return err
+		}
+		_, err = io.Copy(w, resp.Body)
 		return err
-	}
-	_, err = io.Copy(w, resp.Body)
-	return err
+	})
 }
 
 // GetTrademarkLastUpdate retrieves the last update time for a trademark case.
@@ -237,7 +239,7 @@ func (c *Client) GetTrademarkLastUpdate(ctx context.Context, serialNumber string
 	}
 
 	var resp *tsdrgen.GetcaseUpdateInfoResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryLoop(ctx, func(ctx context.Context) error {
 		resp = nil
 		var err error
 		params := &tsdrgen.GetcaseUpdateInfoParams{Sn: serialNumber}
@@ -245,7 +247,7 @@ func (c *Client) GetTrademarkLastUpdate(ctx context.Context, serialNumber string
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		if err := checkEmptyBody(resp.StatusCode(), resp.Body); err != nil {
@@ -272,7 +274,7 @@ func (c *Client) GetTrademarkMultiStatus(ctx context.Context, pType string, numb
 	}
 
 	var resp *tsdrgen.GetListResponse
-	err := c.retryableRequest(ctx, func() error {
+	err := c.retryLoop(ctx, func(ctx context.Context) error {
 		resp = nil
 		var err error
 		params := &tsdrgen.GetListParams{Ids: numbers}
@@ -280,7 +282,7 @@ func (c *Client) GetTrademarkMultiStatus(ctx context.Context, pType string, numb
 		if err != nil {
 			return err
 		}
-		if err := checkResponseStatus(resp.StatusCode(), resp.Body, headerOf(resp.HTTPResponse)); err != nil {
+		if err := checkResponseStatus(resp.StatusCode(), resp.Body, resp.HTTPResponse); err != nil {
 			return err
 		}
 		if err := checkEmptyBody(resp.StatusCode(), resp.Body); err != nil {
@@ -1,7 +1,12 @@
 package odp
 
 import (
+	"encoding/json"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/patent-dev/uspto-odp/generated"
 )
@@ -13,13 +18,29 @@ type AssociatedDocumentsResponse struct {
 	PgpubDocumentMetaData *generated.PGPubFileMetaData
 }
 
+// BulkProductSummary holds the key facts about a bulk data product, as
+// returned by GetBulkProductSummary.
+type BulkProductSummary struct {
+	ProductID    string
+	Title        string
+	Frequency    string // e.g. "WEEKLY", "DAILY"
+	DaysOfWeek   string // release day for weekly products, e.g. "TUESDAY"
+	FromDate     string // yyyy-MM-dd
+	ToDate       string // yyyy-MM-dd
+	TotalSize    int64  // bytes across all files
+	FileCount    int64
+	LastModified string
+}
+
 // ContinuityParent represents a parent application in a continuity chain.
 type ContinuityParent struct {
 	ApplicationNumber string
 	PatentNumber      string
 	FilingDate        string
 	Status            string
+	StatusCode        *int // nil when the API omits it
 	RelationshipType  string
+	RelationshipCode  string // claim parentage type code, e.g. "CON", "NST"
 }
 
 // ContinuityChild represents a child application in a continuity chain.
@@ -28,7 +49,19 @@ type ContinuityChild struct {
 	PatentNumber      string
 	FilingDate        string
 	Status            string
+	StatusCode        *int // nil when the API omits it
 	RelationshipType  string
+	RelationshipCode  string // claim parentage type code, e.g. "CON", "NST"
+}
+
+// ParsedFilingDate returns FilingDate as a time.Time.
+func (p ContinuityParent) ParsedFilingDate() (t time.Time, ok bool) {
+	return parseDate(p.FilingDate)
+}
+
+// ParsedFilingDate returns FilingDate as a time.Time.
+func (c ContinuityChild) ParsedFilingDate() (t time.Time, ok bool) {
+	return parseDate(c.FilingDate)
 }
 
 // ContinuityResponse contains patent continuity data (parent/child application chain).
@@ -38,6 +71,66 @@ type ContinuityResponse struct {
 	Children          []ContinuityChild
 }
 
+// FileWrapperDocument is one document of an application's file wrapper,
+// flattened from a generated.DocumentBag entry. ApplicationNumber is the
+// application the document was listed under, which for a merged family
+// listing is not necessarily the one originally asked about.
+type FileWrapperDocument struct {
+	ApplicationNumber string
+	Identifier        string
+	Code              string // e.g. "CTNF", "EGRANT.PDF"
+	Description       string
+	Direction         string // "INCOMING", "OUTGOING" or "INTERNAL"
+	OfficialDate      string
+	Downloads         []DocumentDownload
+}
+
+// ParsedOfficialDate returns OfficialDate, e.g.
+// "2023-07-11T00:00:00.000-0400", as a time.Time with its zone offset.
+func (d FileWrapperDocument) ParsedOfficialDate() (t time.Time, ok bool) {
+	return parseDate(d.OfficialDate)
+}
+
+// DocumentDownload is one downloadable format of a file wrapper document.
+type DocumentDownload struct {
+	URL      string
+	MimeType string // "PDF", "XML", "MS_WORD"
+	Pages    int
+}
+
+// documentsFromBag flattens a GetPatentDocuments response, tagging every
+// entry with applicationNumber.
+func documentsFromBag(applicationNumber string, bag *generated.DocumentBag) []FileWrapperDocument {
+	if bag == nil || bag.DocumentBag == nil {
+		return nil
+	}
+	docs := make([]FileWrapperDocument, 0, len(*bag.DocumentBag))
+	for _, d := range *bag.DocumentBag {
+		doc := FileWrapperDocument{
+			ApplicationNumber: applicationNumber,
+			Identifier:        derefStr(d.DocumentIdentifier),
+			Code:              derefStr(d.DocumentCode),
+			Description:       derefStr(d.DocumentCodeDescriptionText),
+			Direction:         derefStr(d.DirectionCategory),
+			OfficialDate:      derefStr(d.OfficialDate),
+		}
+		if doc.Direction == "" {
+			doc.Direction = derefStr(d.DocumentDirectionCategory)
+		}
+		if d.DownloadOptionBag != nil {
+			for _, o := range *d.DownloadOptionBag {
+				doc.Downloads = append(doc.Downloads, DocumentDownload{
+					URL:      derefStr(o.DownloadUrl),
+					MimeType: derefStr(o.MimeTypeIdentifier),
+					Pages:    derefInt(o.PageTotalQuantity),
+				})
+			}
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
 // Assignor is a transferring party on an assignment recordation. The USPTO
 // schema only carries name + execution date for assignors (no address).
 type Assignor struct {
@@ -58,14 +151,28 @@ type Assignee struct {
 
 // AssignmentEntry represents a single patent assignment record.
 type AssignmentEntry struct {
-	Assignors           []Assignor
-	Assignees           []Assignee
-	RecordedDate        string
-	Conveyance          string
-	ReelFrame           string
+	Assignors    []Assignor
+	Assignees    []Assignee
+	RecordedDate string
+	Conveyance   string
+	ReelFrame    string
+	// ReelNumber and FrameNumber locate the recordation on microfilm. They
+	// come from the API's numeric fields, or are parsed from ReelFrame when
+	// those are absent; zero means unknown.
+	ReelNumber          int
+	FrameNumber         int
 	MailedDate          string
 	ReceivedDate        string
 	DocumentLocationURI string
+	// PageCount is the number of pages in the recorded document.
+	PageCount int
+	// ImageAvailable reports whether the recorded document can be
+	// retrieved from DocumentLocationURI.
+	ImageAvailable       bool
+	AttorneyDocketNumber string
+	// CorrespondentName is who the recordation notice was sent to,
+	// typically the law firm that filed it.
+	CorrespondentName string
 }
 
 // AssignmentResponse contains patent assignment/ownership data.
@@ -74,6 +181,28 @@ type AssignmentResponse struct {
 	Assignments       []AssignmentEntry
 }
 
+// OrderedChain returns the assignments in chain-of-title order: by recorded
+// date, then by ReelNumber and FrameNumber as tiebreakers for recordations
+// made on the same day. Entries without a recorded date sort last. The
+// receiver is not modified.
+func (r *AssignmentResponse) OrderedChain() []AssignmentEntry {
+	chain := append([]AssignmentEntry(nil), r.Assignments...)
+	sort.SliceStable(chain, func(i, j int) bool {
+		a, b := chain[i], chain[j]
+		if a.RecordedDate != b.RecordedDate {
+			if a.RecordedDate == "" || b.RecordedDate == "" {
+				return b.RecordedDate == ""
+			}
+			return a.RecordedDate < b.RecordedDate
+		}
+		if a.ReelNumber != b.ReelNumber {
+			return a.ReelNumber < b.ReelNumber
+		}
+		return a.FrameNumber < b.FrameNumber
+	})
+	return chain
+}
+
 // AdjustmentResponse contains patent term adjustment data.
 type AdjustmentResponse struct {
 	ApplicationNumber   string
@@ -81,6 +210,38 @@ type AdjustmentResponse struct {
 	ADelays             int
 	BDelays             int
 	CDelays             int
+	OverlapDays         int // A/B/C delay days counted more than once
+	ApplicantDelays     int // reductions for applicant delay
+	OfficeAdjustments   int // manual +/- adjustments by the Office, e.g. on reconsideration
+	History             []AdjustmentEvent
+}
+
+// ComputedAdjustment recomputes the adjustment from its components:
+// A + B + C delays, less overlapping days, plus any manual Office
+// adjustment, less applicant delay. For a consistent record this equals
+// TotalAdjustmentDays; see HasDiscrepancy.
+func (a *AdjustmentResponse) ComputedAdjustment() int {
+	return a.ADelays + a.BDelays + a.CDelays - a.OverlapDays + a.OfficeAdjustments - a.ApplicantDelays
+}
+
+// HasDiscrepancy reports whether the total USPTO reports disagrees with
+// ComputedAdjustment - usually a sign that the record is mid-update or that
+// a delay component is missing from the response.
+func (a *AdjustmentResponse) HasDiscrepancy() bool {
+	return a.ComputedAdjustment() != a.TotalAdjustmentDays
+}
+
+// AdjustmentEvent is one entry of the patent term adjustment history.
+// Sequence numbers are fractional (e.g. 64.5) because USPTO inserts events
+// between existing ones, so they are float64 rather than int.
+type AdjustmentEvent struct {
+	Date                      string
+	Description               string
+	Code                      string // "PTA" or "PTE"
+	SequenceNumber            float64
+	OriginatingSequenceNumber float64
+	ApplicantDays             int
+	OfficeDays                int
 }
 
 // CorrespondenceAddress represents a single mailing-address record on an
@@ -122,12 +283,149 @@ type Inventor struct {
 	CorrespondenceAddressBag []CorrespondenceAddress
 }
 
+// NormalizedName returns the inventor's name in the canonical "Last, First"
+// form (see NormalizeInventorName). FirstName carries the middle name when
+// the API supplies one separately.
+func (i Inventor) NormalizedName() string {
+	first := strings.TrimSpace(i.FirstName + " " + i.MiddleName)
+	return NormalizeInventorName(first, i.LastName, i.InventorNameText)
+}
+
+// NormalizeInventorName returns an inventor name as "Last, First" with each
+// name part in title case, so that the split-field form ("Hiroshi",
+// "ASAHARA"), the comma form ("ASAHARA,Hiroshi") and the space form
+// ("Hiroshi ASAHARA") all yield "Asahara, Hiroshi". The split fields win when
+// last is set; otherwise full is parsed. In the space form the surname is the
+// word written in capitals when exactly one is, else the last word. Title
+// casing is lossy for names such as "McDonald"; compare normalized names
+// rather than displaying them when that matters.
+func NormalizeInventorName(first, last, full string) string {
+	first, last = normalizeSpace(first), normalizeSpace(last)
+	if last == "" {
+		full = normalizeSpace(full)
+		if l, f, ok := strings.Cut(full, ","); ok {
+			last, first = strings.TrimSpace(l), strings.TrimSpace(f)
+		} else if words := strings.Fields(full); len(words) > 0 {
+			surname := len(words) - 1
+			upper := -1
+			for i, w := range words {
+				if isAllUpper(w) {
+					if upper >= 0 {
+						upper = -1
+						break
+					}
+					upper = i
+				}
+			}
+			if upper >= 0 && len(words) > 1 {
+				surname = upper
+			}
+			last = words[surname]
+			first = strings.Join(append(append([]string(nil), words[:surname]...), words[surname+1:]...), " ")
+		}
+	}
+	last, first = titleCaseName(last), titleCaseName(first)
+	switch {
+	case last == "":
+		return first
+	case first == "":
+		return last
+	default:
+		return last + ", " + first
+	}
+}
+
+// isAllUpper reports whether w has at least two letters and no lower-case
+// ones ("ASAHARA", but not "J." or "Hiroshi").
+func isAllUpper(w string) bool {
+	letters := 0
+	for _, r := range w {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	return letters > 1
+}
+
+// titleCaseName upper-cases the first letter of each name segment and
+// lower-cases the rest. Segments are split at spaces, hyphens and
+// apostrophes, so "JEAN-LUC O'NEIL" becomes "Jean-Luc O'Neil".
+func titleCaseName(s string) string {
+	var b strings.Builder
+	start := true
+	for _, r := range s {
+		if start {
+			b.WriteRune(unicode.ToUpper(r))
+		} else {
+			b.WriteRune(unicode.ToLower(r))
+		}
+		start = r == ' ' || r == '-' || r == '\''
+	}
+	return b.String()
+}
+
 // EntityStatus indicates the applicant's fee-entity status.
 type EntityStatus struct {
 	BusinessEntityStatusCategory string
 	SmallEntityStatusIndicator   *bool
 }
 
+// PatentFileWrapper is one application record of a patent search or lookup.
+// The generated client declares these records as an anonymous struct inside
+// generated.PatentDataResponse; this named type has the identical layout, so
+// an element of PatentFileWrapperDataBag converts to it directly:
+//
+//	rec := odp.PatentFileWrapper((*resp.PatentFileWrapperDataBag)[0])
+type PatentFileWrapper struct {
+	// ApplicationMetaData Represents patent application meta data properties
+	ApplicationMetaData *generated.ApplicationMetaData `json:"applicationMetaData,omitempty"`
+
+	// ApplicationNumberText Free format of application number
+	ApplicationNumberText *string                          `json:"applicationNumberText,omitempty"`
+	AssignmentBag         *[]generated.Assignment          `json:"assignmentBag,omitempty"`
+	ChildContinuityBag    *[]generated.ChildContinuityData `json:"childContinuityBag,omitempty"`
+
+	// CorrespondenceAddressBag Collection of correspondences
+	CorrespondenceAddressBag *[]struct {
+		AddressLineOneText    *string `json:"addressLineOneText,omitempty"`
+		AddressLineTwoText    *string `json:"addressLineTwoText,omitempty"`
+		CityName              *string `json:"cityName,omitempty"`
+		CountryCode           *string `json:"countryCode,omitempty"`
+		CountryName           *string `json:"countryName,omitempty"`
+		GeographicRegionCode  *string `json:"geographicRegionCode,omitempty"`
+		GeographicRegionName  *string `json:"geographicRegionName,omitempty"`
+		NameLineOneText       *string `json:"nameLineOneText,omitempty"`
+		NameLineTwoText       *string `json:"nameLineTwoText,omitempty"`
+		PostalAddressCategory *string `json:"postalAddressCategory,omitempty"`
+		PostalCode            *string `json:"postalCode,omitempty"`
+	} `json:"correspondenceAddressBag,omitempty"`
+	EventDataBag       *[]generated.EventData       `json:"eventDataBag,omitempty"`
+	ForeignPriorityBag *[]generated.ForeignPriority `json:"foreignPriorityBag,omitempty"`
+
+	// GrantDocumentMetaData Contains patent grant zip and xml file meta data for an application
+	GrantDocumentMetaData *generated.GrantFileMetaData      `json:"grantDocumentMetaData,omitempty"`
+	LastIngestionDateTime *string                           `json:"lastIngestionDateTime,omitempty"`
+	ParentContinuityBag   *[]generated.ParentContinuityData `json:"parentContinuityBag,omitempty"`
+
+	// PatentTermAdjustmentData Patent term adjustment data
+	PatentTermAdjustmentData *generated.PatentTermAdjustment `json:"patentTermAdjustmentData,omitempty"`
+
+	// PgpubDocumentMetaData Contains pgpub zip and xml file meta data for an application
+	PgpubDocumentMetaData *generated.PGPubFileMetaData `json:"pgpubDocumentMetaData,omitempty"`
+
+	// RecordAttorney An attorney selected by the applicant or owner of an intellectual property to represent them before the national office.
+	RecordAttorney *generated.RecordAttorney `json:"recordAttorney,omitempty"`
+}
+
+// Compile-time check that PatentFileWrapper still matches the generated
+// record layout; regenerating the client with a changed schema fails here.
+var _ = func(r *generated.PatentDataResponse) PatentFileWrapper {
+	return PatentFileWrapper((*r.PatentFileWrapperDataBag)[0])
+}
+
 // MetaDataResponse contains the full patent application meta-data response.
 // MetaDataResponse pointer fields use *T (rather than T) when the API
 // distinguishes "field absent" from the zero value -- for status codes,
@@ -171,6 +469,242 @@ type MetaDataResponse struct {
 	EntityStatus                             *EntityStatus
 	Applicants                               []Applicant
 	Inventors                                []Inventor
+	// Extra holds the record's unmodeled fields by dotted path (see
+	// PatentRecord). Only filled when Config.CaptureUnknownFields is set.
+	Extra map[string]json.RawMessage
+}
+
+// DocumentStage reports how far the application has progressed: Grant once
+// it has a patent number, Publication once it has a pre-grant publication,
+// otherwise Application.
+func (m *MetaDataResponse) DocumentStage() DocumentStage {
+	switch {
+	case m.PatentNumber != "":
+		return DocumentStageGrant
+	case m.EarliestPublicationNumber != "":
+		return DocumentStagePublication
+	default:
+		return DocumentStageApplication
+	}
+}
+
+// KindCode returns the kind code of the application's latest document. The
+// metadata carries no grant kind code, so for grants it is derived the way
+// the USPTO assigns it: S for designs, E for reissues, P2/P3 for plants and
+// B1/B2 for utility patents (the 2 when a pre-grant publication exists). For
+// publications it is taken from EarliestPublicationNumber, defaulting to A1.
+// Unpublished applications have no kind code.
+func (m *MetaDataResponse) KindCode() string {
+	published := m.EarliestPublicationNumber != ""
+	switch m.DocumentStage() {
+	case DocumentStageGrant:
+		switch m.ApplicationType() {
+		case ApplicationTypeDesign:
+			return "S"
+		case ApplicationTypeReissue:
+			return "E"
+		case ApplicationTypePlant:
+			if published {
+				return "P3"
+			}
+			return "P2"
+		}
+		if published {
+			return "B2"
+		}
+		return "B1"
+	case DocumentStagePublication:
+		if pn, err := NormalizePatentNumber(m.EarliestPublicationNumber); err == nil && pn.KindCode != "" {
+			return pn.KindCode
+		}
+		return "A1"
+	default:
+		return ""
+	}
+}
+
+// DocumentLabel returns a display label for the application's latest
+// document, e.g. "B2 Grant", "A1 Publication" or "Application".
+func (m *MetaDataResponse) DocumentLabel() string {
+	return DocumentLabel(m.DocumentStage(), m.KindCode())
+}
+
+// IsFirstInventorToFile reports whether the application is examined under
+// the AIA first-inventor-to-file provisions. The API encodes the field as a
+// "Y"/"N" string.
+func (m *MetaDataResponse) IsFirstInventorToFile() bool {
+	return ParseIndicator(m.FirstInventorToFileIndicator)
+}
+
+// IsNationalStage reports whether the application entered the US national
+// stage from a PCT application. A missing field is reported as false.
+func (m *MetaDataResponse) IsNationalStage() bool {
+	return boolValue(m.NationalStageIndicator)
+}
+
+// IsSmallEntity reports whether the applicant claims small entity status.
+// A missing entity status is reported as false.
+func (m *MetaDataResponse) IsSmallEntity() bool {
+	if m.EntityStatus == nil {
+		return false
+	}
+	return boolValue(m.EntityStatus.SmallEntityStatusIndicator)
+}
+
+// TechnologyCenter returns the examining technology center, e.g. "3600",
+// which the metadata does not carry but which is the group art unit's
+// hundreds: art unit 3689 belongs to TC 3600. It returns "" when
+// GroupArtUnitNumber is not a four-digit art unit.
+func (m *MetaDataResponse) TechnologyCenter() string {
+	gau := strings.TrimSpace(m.GroupArtUnitNumber)
+	if len(gau) != 4 || strings.Trim(gau, "0123456789") != "" {
+		return ""
+	}
+	return gau[:2] + "00"
+}
+
+// ApplicationType is the normalized kind of a patent application.
+type ApplicationType int
+
+// Application type values.
+const (
+	ApplicationTypeUnknown ApplicationType = iota
+	ApplicationTypeUtility
+	ApplicationTypeDesign
+	ApplicationTypePlant
+	ApplicationTypeReissue
+	ApplicationTypeReexamination
+	ApplicationTypeProvisional
+	ApplicationTypePCT
+)
+
+// String returns a human-readable name for the application type.
+func (t ApplicationType) String() string {
+	switch t {
+	case ApplicationTypeUtility:
+		return "utility"
+	case ApplicationTypeDesign:
+		return "design"
+	case ApplicationTypePlant:
+		return "plant"
+	case ApplicationTypeReissue:
+		return "reissue"
+	case ApplicationTypeReexamination:
+		return "reexamination"
+	case ApplicationTypeProvisional:
+		return "provisional"
+	case ApplicationTypePCT:
+		return "pct"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseApplicationType normalizes the three application-type fields the API
+// returns (applicationTypeCode "UTL", applicationTypeLabelName "Utility",
+// applicationTypeCategory "REGULAR") into an ApplicationType. A specific
+// category (REISSUE, REEXAM, PCT, PROVISIONAL) wins over the code, the code
+// over the label. A national-stage application is examined as a regular US
+// application and so reports its own type (usually Utility); use
+// MetaDataResponse.IsNationalStage to tell it apart.
+func ParseApplicationType(code, label, category string) ApplicationType {
+	switch strings.ToUpper(strings.TrimSpace(category)) {
+	case "REISSUE":
+		return ApplicationTypeReissue
+	case "REEXAM", "REEXAMINATION":
+		return ApplicationTypeReexamination
+	case "PCT":
+		return ApplicationTypePCT
+	case "PROVISIONAL":
+		return ApplicationTypeProvisional
+	}
+	switch strings.ToUpper(strings.TrimSpace(code)) {
+	case "UTL":
+		return ApplicationTypeUtility
+	case "DES":
+		return ApplicationTypeDesign
+	case "PLT", "PP":
+		return ApplicationTypePlant
+	case "REI", "RE":
+		return ApplicationTypeReissue
+	case "PRV", "PROV":
+		return ApplicationTypeProvisional
+	case "PCT":
+		return ApplicationTypePCT
+	}
+	switch strings.ToUpper(strings.TrimSpace(label)) {
+	case "UTILITY":
+		return ApplicationTypeUtility
+	case "DESIGN":
+		return ApplicationTypeDesign
+	case "PLANT":
+		return ApplicationTypePlant
+	case "REISSUE":
+		return ApplicationTypeReissue
+	case "PROVISIONAL":
+		return ApplicationTypeProvisional
+	case "PCT":
+		return ApplicationTypePCT
+	}
+	return ApplicationTypeUnknown
+}
+
+// ApplicationType returns the normalized application type. International
+// applications filed with the USPTO as receiving office carry a "PCT"
+// application number and are reported as PCT even without type fields.
+func (m *MetaDataResponse) ApplicationType() ApplicationType {
+	return applicationType(m.ApplicationTypeCode, m.ApplicationTypeLabelName, m.ApplicationTypeCategory, m.ApplicationNumber)
+}
+
+// ApplicationType returns the normalized application type from the record's
+// ApplicationMetaData, like MetaDataResponse.ApplicationType. It is
+// ApplicationTypeUnknown when the record has no metadata or type fields.
+func (p *PatentFileWrapper) ApplicationType() ApplicationType {
+	var code, label, category string
+	if md := p.ApplicationMetaData; md != nil {
+		code = derefStr(md.ApplicationTypeCode)
+		label = derefStr(md.ApplicationTypeLabelName)
+		category = derefStr(md.ApplicationTypeCategory)
+	}
+	return applicationType(code, label, category, derefStr(p.ApplicationNumberText))
+}
+
+// applicationType is ParseApplicationType falling back to PCT for a "PCT"
+// application number.
+func applicationType(code, label, category, appNumber string) ApplicationType {
+	t := ParseApplicationType(code, label, category)
+	if t == ApplicationTypeUnknown && strings.HasPrefix(strings.ToUpper(appNumber), "PCT") {
+		return ApplicationTypePCT
+	}
+	return t
+}
+
+// ParsedFilingDate returns FilingDate as a time.Time. ok is false when it
+// is empty or not a recognized date; see ParseUSPTODate.
+func (m *MetaDataResponse) ParsedFilingDate() (t time.Time, ok bool) {
+	return parseDate(m.FilingDate)
+}
+
+// ParsedEffectiveFilingDate returns EffectiveFilingDate as a time.Time.
+func (m *MetaDataResponse) ParsedEffectiveFilingDate() (t time.Time, ok bool) {
+	return parseDate(m.EffectiveFilingDate)
+}
+
+// ParsedGrantDate returns GrantDate as a time.Time. ok is false for an
+// application that has not been granted.
+func (m *MetaDataResponse) ParsedGrantDate() (t time.Time, ok bool) {
+	return parseDate(m.GrantDate)
+}
+
+// ParsedEarliestPublicationDate returns EarliestPublicationDate as a
+// time.Time.
+func (m *MetaDataResponse) ParsedEarliestPublicationDate() (t time.Time, ok bool) {
+	return parseDate(m.EarliestPublicationDate)
+}
+
+// ParsedApplicationStatusDate returns ApplicationStatusDate as a time.Time.
+func (m *MetaDataResponse) ParsedApplicationStatusDate() (t time.Time, ok bool) {
+	return parseDate(m.ApplicationStatusDate)
 }
 
 // ForeignPriorityClaim represents a single foreign priority claim.
@@ -186,13 +720,21 @@ type ForeignPriorityResponse struct {
 	Claims            []ForeignPriorityClaim
 }
 
-// TransactionEvent represents a single patent transaction event.
+// TransactionEvent represents a single patent transaction event. Date is the
+// raw eventDate string as USPTO sent it; the format is not consistent across
+// records, so ParsedDate parses it on demand.
 type TransactionEvent struct {
 	Date        string
 	Code        string
 	Description string
 }
 
+// ParsedDate returns Date as a time.Time. ok is false when it is empty or
+// not a recognized date; see ParseUSPTODate.
+func (e TransactionEvent) ParsedDate() (t time.Time, ok bool) {
+	return parseDate(e.Date)
+}
+
 // TransactionsResponse contains patent transaction history.
 type TransactionsResponse struct {
 	ApplicationNumber string
@@ -210,6 +752,14 @@ func float32ToIntPtr(p *float32) *int {
 	return &v
 }
 
+// parseAPIDateTime parses an API timestamp, with or without a zone offset.
+func parseAPIDateTime(s *string) (time.Time, bool) {
+	if s == nil {
+		return time.Time{}, false
+	}
+	return parseDate(*s)
+}
+
 // derefStr safely dereferences a *string pointer, returning "" if nil.
 func derefStr(p *string) string {
 	if p == nil {
@@ -226,6 +776,35 @@ func derefInt(p *int) int {
 	return *p
 }
 
+// ParseIndicator normalizes the string-encoded indicator values the API
+// uses in place of JSON booleans. "Y"/"N" (firstInventorToFileIndicator) and
+// "ACTIVE"/"INACTIVE" (attorney activeIndicator) are the encodings seen in
+// practice; "YES", "TRUE" and "1" are accepted for robustness. Matching is
+// case-insensitive and ignores surrounding whitespace. Anything else,
+// including the empty string, is false.
+func ParseIndicator(s string) bool {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "Y", "YES", "TRUE", "1", "ACTIVE":
+		return true
+	default:
+		return false
+	}
+}
+
+// boolValue dereferences a native *bool indicator, returning false if nil.
+func boolValue(p *bool) bool {
+	return p != nil && *p
+}
+
+// parseReelFrame splits a "reel/frame" string such as "038323/0190" into its
+// numeric parts. Unparseable parts are returned as 0.
+func parseReelFrame(s string) (reel, frame int) {
+	reelText, frameText, _ := strings.Cut(s, "/")
+	reel, _ = strconv.Atoi(strings.TrimSpace(reelText))
+	frame, _ = strconv.Atoi(strings.TrimSpace(frameText))
+	return reel, frame
+}
+
 // mapRelationshipType converts a claim parentage type code to human-readable
 // form. Best-effort: the four common codes (CON, DIV, CIP, PRO) get a
 // canonical English label; other codes (REI, REX, NST, historical codes)
@@ -248,3 +827,140 @@ func mapRelationshipType(code, description string) string {
 		return code
 	}
 }
+
+// Practitioner is a registered attorney or agent of record, flattened from
+// a generated.RecordAttorney.
+type Practitioner struct {
+	RegistrationNumber string
+	FirstName          string
+	LastName           string
+	Category           string // "ATTNY" or "AGENT"
+	Active             bool
+	PowerOfAttorney    bool // listed in powerOfAttorneyBag, not only attorneyBag
+}
+
+// Practitioners lists the practitioners of a GetPatentAttorney response,
+// each registration number once: first those holding power of attorney,
+// then the remaining attorneyBag entries, in API order.
+func Practitioners(r *generated.RecordAttorney) []Practitioner {
+	if r == nil {
+		return nil
+	}
+	var out []Practitioner
+	seen := make(map[string]bool)
+	add := func(p Practitioner) {
+		if p.RegistrationNumber != "" {
+			if seen[p.RegistrationNumber] {
+				return
+			}
+			seen[p.RegistrationNumber] = true
+		}
+		out = append(out, p)
+	}
+	if r.PowerOfAttorneyBag != nil {
+		for _, a := range *r.PowerOfAttorneyBag {
+			add(Practitioner{
+				RegistrationNumber: derefStr(a.RegistrationNumber),
+				FirstName:          derefStr(a.FirstName),
+				LastName:           derefStr(a.LastName),
+				Category:           derefStr(a.RegisteredPractitionerCategory),
+				Active:             ParseIndicator(derefStr(a.ActiveIndicator)),
+				PowerOfAttorney:    true,
+			})
+		}
+	}
+	if r.AttorneyBag != nil {
+		for _, a := range *r.AttorneyBag {
+			add(Practitioner{
+				RegistrationNumber: derefStr(a.RegistrationNumber),
+				FirstName:          derefStr(a.FirstName),
+				LastName:           derefStr(a.LastName),
+				Category:           derefStr(a.RegisteredPractitionerCategory),
+				Active:             ParseIndicator(derefStr(a.ActiveIndicator)),
+			})
+		}
+	}
+	return out
+}
+
+// Petition is a petition decision record with typed accessors for its coded
+// fields. The embedded generated record keeps every raw field reachable.
+type Petition struct {
+	generated.PetitionDecision
+}
+
+// PetitionsFromSearch wraps the records of a SearchPetitions response.
+func PetitionsFromSearch(resp *generated.PetitionDecisionResponseBag) []Petition {
+	if resp == nil {
+		return nil
+	}
+	return wrapPetitions(resp.PetitionDecisionDataBag)
+}
+
+// PetitionsFromDecision wraps the records of a GetPetitionDecision response.
+func PetitionsFromDecision(resp *generated.PetitionDecisionIdentifierResponseBag) []Petition {
+	if resp == nil {
+		return nil
+	}
+	return wrapPetitions(resp.PetitionDecisionDataBag)
+}
+
+func wrapPetitions(bag *[]generated.PetitionDecision) []Petition {
+	if bag == nil {
+		return nil
+	}
+	out := make([]Petition, len(*bag))
+	for i, p := range *bag {
+		out[i] = Petition{PetitionDecision: p}
+	}
+	return out
+}
+
+// DecisionType returns the decision outcome as text, e.g. "DENIED" or
+// "GRANTED", falling back to the decision type code (e.g. "C") when the API
+// omits the description.
+func (p Petition) DecisionType() string {
+	if d := derefStr(p.DecisionTypeCodeDescriptionText); d != "" {
+		return d
+	}
+	return derefStr(p.DecisionTypeCode)
+}
+
+// PetitionTypeCode returns the numeric petition type (decisionPetitionTypeCode,
+// e.g. 502), or 0 when absent.
+func (p Petition) PetitionTypeCode() int {
+	return derefInt(p.DecisionPetitionTypeCode)
+}
+
+// PetitionType returns the description of the petition type, when provided.
+func (p Petition) PetitionType() string {
+	return derefStr(p.DecisionPetitionTypeCodeDescriptionText)
+}
+
+// MailDate returns petitionMailDate as a date. ok is false when the field is
+// absent or not a recognized date; see ParseUSPTODate.
+func (p Petition) MailDate() (t time.Time, ok bool) {
+	return parseDate(derefStr(p.PetitionMailDate))
+}
+
+// LastIngestion returns lastIngestionDateTime, when the record was last
+// loaded into ODP. The API sends it without a zone offset (e.g.
+// "2025-07-16T19:49:16"); such values are returned as UTC.
+func (p Petition) LastIngestion() (t time.Time, ok bool) {
+	return parseAPIDateTime(p.LastIngestionDateTime)
+}
+
+// Issues returns the issues the decision considered, one per entry of
+// petitionIssueConsideredTextBag, trimmed and without blanks.
+func (p Petition) Issues() []string {
+	if p.PetitionIssueConsideredTextBag == nil {
+		return nil
+	}
+	var issues []string
+	for _, issue := range *p.PetitionIssueConsideredTextBag {
+		if issue = strings.TrimSpace(issue); issue != "" {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
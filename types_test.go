@@ -7,6 +7,8 @@ import (
 	"os"
 	"strings"
 	"testing"
+
+	"github.com/patent-dev/uspto-odp/generated"
 )
 
 // setupFixtureServer creates a mock server that serves a JSON fixture file at the given path.
@@ -142,6 +144,99 @@ func TestGetPatentContinuity_WithChildren(t *testing.T) {
 	}
 }
 
+func TestGetPatentContinuity_PCTParent(t *testing.T) {
+	// A national-stage entry lists its PCT parent in the slashed display
+	// form, which the API does not accept back as a path parameter.
+	parentJSON := `{"count":1,"patentFileWrapperDataBag":[{"applicationNumberText":"18555555","parentContinuityBag":[{"parentApplicationNumberText":"PCT/US2023/017788","parentApplicationFilingDate":"2023-04-06","claimParentageTypeCode":"NST","claimParentageTypeCodeDescriptionText":"is a National Stage Entry of"}]}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(parentJSON))
+	}))
+	defer server.Close()
+	config := DefaultConfig()
+	config.BaseURL = server.URL
+	config.APIKey = "test-key"
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	result, err := client.GetPatentContinuity(context.Background(), "18555555")
+	if err != nil {
+		t.Fatalf("GetPatentContinuity failed: %v", err)
+	}
+	if len(result.Parents) != 1 || result.Parents[0].ApplicationNumber != "PCTUS2023017788" {
+		t.Errorf("Parents = %+v, want PCT parent PCTUS2023017788", result.Parents)
+	}
+}
+
+func TestGetPatentContinuity_Codes(t *testing.T) {
+	body := `{"count":1,"patentFileWrapperDataBag":[{"applicationNumberText":"16000001",` +
+		`"parentContinuityBag":[{"parentApplicationNumberText":"15000001","parentApplicationStatusCode":150,"parentApplicationStatusDescriptionText":"Patented Case","claimParentageTypeCode":"CON","claimParentageTypeCodeDescriptionText":"is a Continuation of"}],` +
+		`"childContinuityBag":[{"childApplicationNumberText":"17000001","childApplicationStatusCode":30,"childApplicationStatusDescriptionText":"Docketed New Case - Ready for Examination","claimParentageTypeCode":"REI","claimParentageTypeCodeDescriptionText":"is a Reissue of"}]}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+	config := DefaultConfig()
+	config.BaseURL = server.URL
+	config.APIKey = "test-key"
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	result, err := client.GetPatentContinuity(context.Background(), "16000001")
+	if err != nil {
+		t.Fatalf("GetPatentContinuity failed: %v", err)
+	}
+	if len(result.Parents) != 1 || len(result.Children) != 1 {
+		t.Fatalf("got %d parents, %d children; want 1 each", len(result.Parents), len(result.Children))
+	}
+	p := result.Parents[0]
+	if p.StatusCode == nil || *p.StatusCode != 150 || p.RelationshipCode != "CON" || p.RelationshipType != "Continuation" {
+		t.Errorf("parent = %+v, want status code 150, code CON", p)
+	}
+	ch := result.Children[0]
+	if ch.StatusCode == nil || *ch.StatusCode != 30 || ch.RelationshipCode != "REI" || ch.RelationshipType != "is a Reissue of" {
+		t.Errorf("child = %+v, want status code 30, code REI", ch)
+	}
+}
+
+func TestPractitioners(t *testing.T) {
+	client, cleanup := setupFixtureServer(t,
+		"/api/v1/patent/applications/17248024/attorney",
+		"testdata/strictdecode/get_patent_attorney.json")
+	defer cleanup()
+
+	ra, err := client.GetPatentAttorney(context.Background(), "17248024")
+	if err != nil {
+		t.Fatalf("GetPatentAttorney failed: %v", err)
+	}
+	ps := Practitioners(ra)
+	// 27 hold power of attorney; attorneyBag repeats 24 of them and adds 6.
+	if len(ps) != 33 {
+		t.Fatalf("got %d practitioners, want 33", len(ps))
+	}
+	first := ps[0]
+	if first.RegistrationNumber != "44137" || first.LastName != "GRIFFITH" || first.Category != "ATTNY" || !first.Active || !first.PowerOfAttorney {
+		t.Errorf("first practitioner = %+v", first)
+	}
+	var onlyListed int
+	for _, p := range ps {
+		if !p.PowerOfAttorney {
+			onlyListed++
+		}
+	}
+	if onlyListed != 6 {
+		t.Errorf("%d practitioners without power of attorney, want 6", onlyListed)
+	}
+	if Practitioners(nil) != nil {
+		t.Error("Practitioners(nil) should be nil")
+	}
+}
+
 func TestGetPatentContinuity_Empty(t *testing.T) {
 	client, cleanup := setupEmptyServer(t, "/api/v1/patent/applications/00000000/continuity")
 	defer cleanup()
@@ -357,3 +452,342 @@ func TestDerefInt(t *testing.T) {
 		t.Errorf("derefInt(nil) = %d, want 0", got)
 	}
 }
+
+func TestParseIndicator(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"Y", true},
+		{"N", false},
+		{"y", true},
+		{" Y ", true},
+		{"ACTIVE", true},
+		{"Active", true},
+		{"INACTIVE", false},
+		{"true", true},
+		{"", false},
+		{"unknown", false},
+	}
+	for _, tt := range tests {
+		if got := ParseIndicator(tt.in); got != tt.want {
+			t.Errorf("ParseIndicator(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMetaDataResponseIndicators(t *testing.T) {
+	yes, no := true, false
+	m := &MetaDataResponse{
+		FirstInventorToFileIndicator: "Y",
+		NationalStageIndicator:       &yes,
+		EntityStatus:                 &EntityStatus{SmallEntityStatusIndicator: &no},
+	}
+	if !m.IsFirstInventorToFile() {
+		t.Error("IsFirstInventorToFile() = false, want true for \"Y\"")
+	}
+	if !m.IsNationalStage() {
+		t.Error("IsNationalStage() = false, want true")
+	}
+	if m.IsSmallEntity() {
+		t.Error("IsSmallEntity() = true, want false")
+	}
+
+	m.EntityStatus.SmallEntityStatusIndicator = &yes
+	if !m.IsSmallEntity() {
+		t.Error("IsSmallEntity() = false, want true")
+	}
+
+	empty := &MetaDataResponse{FirstInventorToFileIndicator: "N"}
+	if empty.IsFirstInventorToFile() {
+		t.Error("IsFirstInventorToFile() = true, want false for \"N\"")
+	}
+	if empty.IsNationalStage() {
+		t.Error("IsNationalStage() = true, want false when absent")
+	}
+	if empty.IsSmallEntity() {
+		t.Error("IsSmallEntity() = true, want false when EntityStatus is nil")
+	}
+}
+
+func TestMetaDataResponseTechnologyCenter(t *testing.T) {
+	for gau, want := range map[string]string{
+		"3689":  "3600",
+		"1712":  "1700",
+		" 2911": "2900",
+		"":      "",
+		"36":    "",
+		"OPAP":  "",
+	} {
+		m := &MetaDataResponse{GroupArtUnitNumber: gau}
+		if got := m.TechnologyCenter(); got != want {
+			t.Errorf("TechnologyCenter() for art unit %q = %q, want %q", gau, got, want)
+		}
+	}
+}
+
+func TestAssignmentResponseOrderedChain(t *testing.T) {
+	r := &AssignmentResponse{
+		ApplicationNumber: "15000001",
+		Assignments: []AssignmentEntry{
+			{RecordedDate: "2019-03-01", ReelNumber: 48123, FrameNumber: 1, Conveyance: "SECURITY INTEREST"},
+			{RecordedDate: "", ReelNumber: 1, FrameNumber: 1, Conveyance: "UNDATED"},
+			{RecordedDate: "2016-04-19", ReelNumber: 38323, FrameNumber: 190, Conveyance: "ASSIGNMENT"},
+			// Same day as the security interest. Ties go by ReelNumber, then
+			// FrameNumber; ReelFrame text is not consulted.
+			{RecordedDate: "2019-03-01", ReelFrame: "999999/0500", ReelNumber: 9999, FrameNumber: 500, Conveyance: "CORRECTIVE ASSIGNMENT"},
+			{RecordedDate: "2019-03-01", ReelNumber: 48123, FrameNumber: 0, Conveyance: "RELEASE"},
+		},
+	}
+	want := []string{"ASSIGNMENT", "CORRECTIVE ASSIGNMENT", "RELEASE", "SECURITY INTEREST", "UNDATED"}
+
+	chain := r.OrderedChain()
+	if len(chain) != len(want) {
+		t.Fatalf("len(OrderedChain()) = %d, want %d", len(chain), len(want))
+	}
+	for i, w := range want {
+		if chain[i].Conveyance != w {
+			t.Errorf("chain[%d] = %q, want %q", i, chain[i].Conveyance, w)
+		}
+	}
+	if r.Assignments[0].Conveyance != "SECURITY INTEREST" {
+		t.Error("OrderedChain reordered the receiver's Assignments")
+	}
+}
+
+func TestParseReelFrame(t *testing.T) {
+	tests := []struct {
+		in          string
+		reel, frame int
+	}{
+		{"038323/0190", 38323, 190},
+		{"048123/0001", 48123, 1},
+		{"12345", 12345, 0},
+		{"", 0, 0},
+		{"abc/def", 0, 0},
+	}
+	for _, tt := range tests {
+		reel, frame := parseReelFrame(tt.in)
+		if reel != tt.reel || frame != tt.frame {
+			t.Errorf("parseReelFrame(%q) = (%d, %d), want (%d, %d)", tt.in, reel, frame, tt.reel, tt.frame)
+		}
+	}
+}
+
+func TestParseApplicationType(t *testing.T) {
+	tests := []struct {
+		code, label, category string
+		want                  ApplicationType
+	}{
+		{"UTL", "Utility", "REGULAR", ApplicationTypeUtility},
+		{"UTL", "", "", ApplicationTypeUtility},
+		{"", "Utility", "", ApplicationTypeUtility},
+		{"DES", "Design", "REGULAR", ApplicationTypeDesign},
+		{"PLT", "Plant", "REGULAR", ApplicationTypePlant},
+		{"", "", "REISSUE", ApplicationTypeReissue},
+		{"UTL", "Utility", "REISSUE", ApplicationTypeReissue},
+		{"", "", "REEXAM", ApplicationTypeReexamination},
+		{"", "", "PCT", ApplicationTypePCT},
+		{" utl ", "", "", ApplicationTypeUtility},
+		{"", "", "REGULAR", ApplicationTypeUnknown},
+		{"", "", "", ApplicationTypeUnknown},
+	}
+	for _, tt := range tests {
+		if got := ParseApplicationType(tt.code, tt.label, tt.category); got != tt.want {
+			t.Errorf("ParseApplicationType(%q, %q, %q) = %v, want %v", tt.code, tt.label, tt.category, got, tt.want)
+		}
+	}
+}
+
+func TestMetaDataResponseApplicationType(t *testing.T) {
+	utility := &MetaDataResponse{
+		ApplicationNumber:        "17248024",
+		ApplicationTypeCode:      "UTL",
+		ApplicationTypeLabelName: "Utility",
+		ApplicationTypeCategory:  "REGULAR",
+	}
+	if got := utility.ApplicationType(); got != ApplicationTypeUtility {
+		t.Errorf("utility ApplicationType() = %v, want utility", got)
+	}
+
+	// A national-stage entry is a regular utility application; the PCT origin
+	// is carried by the national stage indicator.
+	yes := true
+	nationalStage := &MetaDataResponse{
+		ApplicationNumber:       "18863279",
+		ApplicationTypeCode:     "UTL",
+		ApplicationTypeCategory: "REGULAR",
+		NationalStageIndicator:  &yes,
+	}
+	if got := nationalStage.ApplicationType(); got != ApplicationTypeUtility {
+		t.Errorf("national stage ApplicationType() = %v, want utility", got)
+	}
+	if !nationalStage.IsNationalStage() {
+		t.Error("IsNationalStage() = false, want true")
+	}
+
+	// A PCT application number with no type fields is still PCT.
+	pct := &MetaDataResponse{ApplicationNumber: "PCTUS2025058371"}
+	if got := pct.ApplicationType(); got != ApplicationTypePCT {
+		t.Errorf("PCT ApplicationType() = %v, want pct", got)
+	}
+	if got := ApplicationTypePCT.String(); got != "pct" {
+		t.Errorf("ApplicationTypePCT.String() = %q, want pct", got)
+	}
+}
+
+func TestPatentFileWrapperApplicationType(t *testing.T) {
+	design := &PatentFileWrapper{
+		ApplicationNumberText: StringPtr("29900001"),
+		ApplicationMetaData: &generated.ApplicationMetaData{
+			ApplicationTypeCode:      StringPtr("DES"),
+			ApplicationTypeLabelName: StringPtr("Design"),
+			ApplicationTypeCategory:  StringPtr("REGULAR"),
+		},
+	}
+	if got := design.ApplicationType(); got != ApplicationTypeDesign {
+		t.Errorf("design ApplicationType() = %v, want design", got)
+	}
+	reissue := &PatentFileWrapper{ApplicationMetaData: &generated.ApplicationMetaData{
+		ApplicationTypeCode:     StringPtr("UTL"),
+		ApplicationTypeCategory: StringPtr("REISSUE"),
+	}}
+	if got := reissue.ApplicationType(); got != ApplicationTypeReissue {
+		t.Errorf("reissue ApplicationType() = %v, want reissue", got)
+	}
+	pct := &PatentFileWrapper{ApplicationNumberText: StringPtr("PCTUS2025058371")}
+	if got := pct.ApplicationType(); got != ApplicationTypePCT {
+		t.Errorf("PCT ApplicationType() without metadata = %v, want pct", got)
+	}
+	if got := (&PatentFileWrapper{}).ApplicationType(); got != ApplicationTypeUnknown {
+		t.Errorf("empty ApplicationType() = %v, want unknown", got)
+	}
+}
+
+func TestMetaDataResponseDocumentLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		m    MetaDataResponse
+		want string
+	}{
+		{"granted after publication", MetaDataResponse{PatentNumber: "11646472", EarliestPublicationNumber: "US20210210819A1", ApplicationTypeCode: "UTL"}, "B2 Grant"},
+		{"granted unpublished", MetaDataResponse{PatentNumber: "11000001", ApplicationTypeCode: "UTL"}, "B1 Grant"},
+		{"design grant", MetaDataResponse{PatentNumber: "D1000001", ApplicationTypeCode: "DES"}, "S Grant"},
+		{"pre-grant publication", MetaDataResponse{EarliestPublicationNumber: "US20210210819A1"}, "A1 Publication"},
+		{"corrected publication", MetaDataResponse{EarliestPublicationNumber: "US 2021/0210819 A9"}, "A9 Publication"},
+		{"publication without kind", MetaDataResponse{EarliestPublicationNumber: "20210210819"}, "A1 Publication"},
+		{"unpublished application", MetaDataResponse{ApplicationNumber: "18000001"}, "Application"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.DocumentLabel(); got != tt.want {
+				t.Errorf("DocumentLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKindCodeStage(t *testing.T) {
+	tests := map[string]DocumentStage{
+		"A1": DocumentStagePublication,
+		"a2": DocumentStagePublication,
+		"P1": DocumentStagePublication,
+		"B2": DocumentStageGrant,
+		"S":  DocumentStageGrant,
+		"E":  DocumentStageGrant,
+		"P3": DocumentStageGrant,
+		"":   DocumentStageUnknown,
+		"X1": DocumentStageUnknown,
+	}
+	for kind, want := range tests {
+		if got := KindCodeStage(kind); got != want {
+			t.Errorf("KindCodeStage(%q) = %v, want %v", kind, got, want)
+		}
+	}
+}
+
+func TestNormalizeInventorName(t *testing.T) {
+	tests := []struct {
+		name              string
+		first, last, full string
+		want              string
+	}{
+		{"split fields", "Hiroshi", "ASAHARA", "", "Asahara, Hiroshi"},
+		{"comma form", "", "", "ASAHARA,Hiroshi", "Asahara, Hiroshi"},
+		{"space form", "", "", "Hiroshi ASAHARA", "Asahara, Hiroshi"},
+		{"space form surname first", "", "", "ASAHARA Hiroshi", "Asahara, Hiroshi"},
+		{"space form mixed case", "", "", "Hiroshi Asahara", "Asahara, Hiroshi"},
+		{"split fields win over full", "Hiroshi", "Asahara", "ASAHARA,H.", "Asahara, Hiroshi"},
+		{"middle initial", "", "", "Steven J. VISCO", "Visco, Steven J."},
+		{"hyphen and apostrophe", "", "", "JEAN-LUC,o'neil", "Jean-Luc, O'Neil"},
+		{"surname only", "", "", "ASAHARA", "Asahara"},
+		{"empty", "", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeInventorName(tt.first, tt.last, tt.full); got != tt.want {
+				t.Errorf("NormalizeInventorName(%q, %q, %q) = %q, want %q", tt.first, tt.last, tt.full, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInventorNormalizedName(t *testing.T) {
+	inv := Inventor{InventorNameText: "Steven J. Visco", FirstName: "Steven", MiddleName: "J.", LastName: "VISCO"}
+	if got := inv.NormalizedName(); got != "Visco, Steven J." {
+		t.Errorf("NormalizedName() = %q, want %q", got, "Visco, Steven J.")
+	}
+}
+
+func TestPetitionAccessors_Fallbacks(t *testing.T) {
+	p := Petition{PetitionDecision: generated.PetitionDecision{
+		DecisionTypeCode:               StringPtr("A"),
+		PetitionIssueConsideredTextBag: &[]string{" Revival of an abandoned application ", "", "Expedited examination"},
+	}}
+	if got := p.DecisionType(); got != "A" {
+		t.Errorf("DecisionType() without description = %q, want the code A", got)
+	}
+	if got := p.PetitionTypeCode(); got != 0 {
+		t.Errorf("PetitionTypeCode() without code = %d, want 0", got)
+	}
+	want := []string{"Revival of an abandoned application", "Expedited examination"}
+	if got := p.Issues(); len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Issues() = %q, want %q", got, want)
+	}
+	if _, ok := p.MailDate(); ok {
+		t.Error("MailDate() without petitionMailDate should report !ok")
+	}
+	p.PetitionMailDate = StringPtr("2020-05-15T00:00:00.000-0400")
+	if d, ok := p.MailDate(); !ok || d.Day() != 15 {
+		t.Errorf("MailDate() with a timestamp = %v, %v; want 2020-05-15", d, ok)
+	}
+	p.LastIngestionDateTime = StringPtr("2025-07-16T19:49:16.123-04:00")
+	if d, ok := p.LastIngestion(); !ok || d.UTC().Hour() != 23 {
+		t.Errorf("LastIngestion() with offset = %v, %v", d, ok)
+	}
+	if PetitionsFromSearch(nil) != nil || PetitionsFromDecision(nil) != nil {
+		t.Error("nil responses should yield nil")
+	}
+}
+
+func TestAdjustmentResponse_ComputedAdjustment(t *testing.T) {
+	a := &AdjustmentResponse{
+		TotalAdjustmentDays: 412,
+		ADelays:             300,
+		BDelays:             150,
+		CDelays:             10,
+		OverlapDays:         20,
+		OfficeAdjustments:   2,
+		ApplicantDelays:     30,
+	}
+	if got := a.ComputedAdjustment(); got != 412 {
+		t.Errorf("ComputedAdjustment() = %d, want 412", got)
+	}
+	if a.HasDiscrepancy() {
+		t.Error("HasDiscrepancy() = true, want false")
+	}
+	a.TotalAdjustmentDays = 432 // overlap not subtracted upstream
+	if !a.HasDiscrepancy() {
+		t.Error("HasDiscrepancy() = false, want true")
+	}
+}
@@ -3,10 +3,20 @@ package odp
 import (
 	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/patent-dev/uspto-odp/generated"
 )
 
 // XMLDocument represents either a patent grant or application XML document
@@ -58,6 +68,134 @@ type Bibliography struct {
 	PublicationReference *DocumentID `xml:"publication-reference>document-id"`
 	ApplicationReference *DocumentID `xml:"application-reference>document-id"`
 	InventionTitle       []Text      `xml:"invention-title"`
+	// USParties holds applicants and inventors in the current DTDs; Parties
+	// is the pre-2012 (DTD 4.2 and earlier) equivalent. At most one is set.
+	USParties *Parties `xml:"us-parties"`
+	Parties   *Parties `xml:"parties"`
+	Assignees []Party  `xml:"assignees>assignee"`
+
+	ClassificationsCPC     *ClassificationsCPC     `xml:"classifications-cpc"`
+	ClassificationNational *ClassificationNational `xml:"classification-national"`
+
+	// USReferencesCited is the citation list from DTD 4.2 on;
+	// ReferencesCited is the earlier form. At most one is set.
+	USReferencesCited *CitedReferences `xml:"us-references-cited"`
+	ReferencesCited   *CitedReferences `xml:"references-cited"`
+}
+
+// CitedReferences is the list of references cited on a grant. Entries are
+// <us-citation> under DTD 4.2 and later and <citation> before.
+type CitedReferences struct {
+	USCitations []Citation `xml:"us-citation"`
+	Citations   []Citation `xml:"citation"`
+}
+
+// Citation is one cited reference: a patent document (PatCit) or non-patent
+// literature (NPLCit), and who cited it.
+type Citation struct {
+	PatCit   *PatentCitation `xml:"patcit"`
+	NPLCit   *NPLCitation    `xml:"nplcit"`
+	Category string          `xml:"category"` // "cited by examiner", "cited by applicant", ...
+}
+
+// PatentCitation is a cited patent or published application.
+type PatentCitation struct {
+	Num        string     `xml:"num,attr"`
+	DocumentID DocumentID `xml:"document-id"`
+}
+
+// NPLCitation is a cited non-patent literature reference.
+type NPLCitation struct {
+	Num      string        `xml:"num,attr"`
+	OtherCit OtherCitation `xml:"othercit"`
+}
+
+// OtherCitation is the free-text form of a non-patent reference. Titles and
+// journal names are often set in <i> or <b>, so the text is flattened in
+// document order like ClaimText rather than mapped with ",chardata".
+type OtherCitation struct {
+	Text string
+}
+
+// UnmarshalXML flattens an <othercit> element into its in-document-order text.
+func (oc *OtherCitation) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	text, err := flattenElementText(d)
+	if err != nil {
+		return err
+	}
+	oc.Text = text
+	return nil
+}
+
+// ClassificationsCPC holds the CPC classifications of a document: the
+// inventive main symbol and any further symbols.
+type ClassificationsCPC struct {
+	Main    []CPCClassification `xml:"main-cpc>classification-cpc"`
+	Further []CPCClassification `xml:"further-cpc>classification-cpc"`
+}
+
+// CPCClassification is one CPC symbol, split into its parts the way the
+// XML carries it.
+type CPCClassification struct {
+	Section             string `xml:"section"`
+	Class               string `xml:"class"`
+	Subclass            string `xml:"subclass"`
+	MainGroup           string `xml:"main-group"`
+	Subgroup            string `xml:"subgroup"`
+	SymbolPosition      string `xml:"symbol-position"`      // F (first) or L (later)
+	ClassificationValue string `xml:"classification-value"` // I (inventive) or A (additional)
+}
+
+// ClassificationNational holds the USPC classification, in the fixed-width
+// form of the XML: a three-character class followed by the subclass
+// ("705  7", "4241851").
+type ClassificationNational struct {
+	Country string   `xml:"country"`
+	Main    string   `xml:"main-classification"`
+	Further []string `xml:"further-classification"`
+}
+
+// Parties lists the people and organizations named on a document. Which
+// lists are filled depends on the DTD: current documents use us-applicants
+// and inventors, older ones name inventors as applicants with app-type
+// "applicant-inventor".
+type Parties struct {
+	USApplicants []Party `xml:"us-applicants>us-applicant"`
+	Applicants   []Party `xml:"applicants>applicant"`
+	Inventors    []Party `xml:"inventors>inventor"`
+}
+
+// Party is one applicant, inventor or assignee entry. Assignees in some
+// older documents carry orgname and address directly instead of inside an
+// addressbook; those land in OrgName and Address.
+type Party struct {
+	Sequence string `xml:"sequence,attr"`
+	AppType  string `xml:"app-type,attr"`
+	// AuthorityCategory is set on us-applicant entries from DTD 4.3 on:
+	// "assignee", "inventor", "legal-representative", ...
+	AuthorityCategory string       `xml:"applicant-authority-category,attr"`
+	AddressBook       AddressBook  `xml:"addressbook"`
+	OrgName           string       `xml:"orgname"`
+	Address           PartyAddress `xml:"address"`
+}
+
+// AddressBook is the name and address block of a party. Individuals carry
+// first and last names, organizations an orgname.
+type AddressBook struct {
+	OrgName   string       `xml:"orgname"`
+	LastName  string       `xml:"last-name"`
+	FirstName string       `xml:"first-name"`
+	Role      string       `xml:"role"`
+	Address   PartyAddress `xml:"address"`
+}
+
+// PartyAddress is the address of a party; for inventors it is the
+// residence.
+type PartyAddress struct {
+	City     string `xml:"city"`
+	State    string `xml:"state"`
+	Postcode string `xml:"postcode"`
+	Country  string `xml:"country"`
 }
 
 // DocumentID represents document identification
@@ -65,6 +203,7 @@ type DocumentID struct {
 	Country   string `xml:"country"`
 	DocNumber string `xml:"doc-number"`
 	Kind      string `xml:"kind"`
+	Name      string `xml:"name"` // first-named patentee, on cited documents
 	Date      string `xml:"date"`
 }
 
@@ -81,6 +220,45 @@ type Description struct {
 	Lang       string      `xml:"lang,attr"`
 	Headings   []Heading   `xml:"heading"`
 	Paragraphs []Paragraph `xml:"p"`
+	// DescriptionOfDrawings is the "BRIEF DESCRIPTION OF THE DRAWINGS"
+	// block. ICE wraps it in its own element, so its heading and paragraphs
+	// are not part of Headings/Paragraphs above.
+	DescriptionOfDrawings *DescriptionOfDrawings `xml:"description-of-drawings"`
+}
+
+// DescriptionOfDrawings holds the brief description of the drawings: usually
+// one heading followed by one paragraph per figure.
+type DescriptionOfDrawings struct {
+	Headings   []Heading            `xml:"heading"`
+	Paragraphs []DrawingDescription `xml:"p"`
+}
+
+// DrawingDescription is one paragraph of the brief description of the
+// drawings. The figure label sits inside <figref> (often with a <b> around
+// the number), so the paragraph is flattened in document order like
+// ClaimText rather than mapped with ",chardata", which would drop "FIG. 1".
+type DrawingDescription struct {
+	ID   string
+	Num  string
+	Text string
+}
+
+// UnmarshalXML flattens a drawing-description <p> into its in-document-order text.
+func (dd *DrawingDescription) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "id":
+			dd.ID = attr.Value
+		case "num":
+			dd.Num = attr.Value
+		}
+	}
+	text, err := flattenElementText(d)
+	if err != nil {
+		return err
+	}
+	dd.Text = text
+	return nil
 }
 
 // Heading represents a section heading
@@ -90,16 +268,21 @@ type Heading struct {
 	Text  string `xml:",chardata"`
 }
 
-// Paragraph represents a text paragraph with possible nested elements
+// Paragraph represents a text paragraph with possible nested elements.
+// Text holds only the character data directly under <p>, and the Sub, Sup,
+// I and B slices the text of each kind of inline element, so neither keeps
+// the order they interleave in; InnerXML does, and text extraction reads
+// the paragraph from it.
 type Paragraph struct {
 	ID   string `xml:"id,attr"`
 	Num  string `xml:"num,attr"`
 	Text string `xml:",chardata"`
 	// Support for nested elements
-	Sub []Sub    `xml:"sub"`
-	Sup []Sup    `xml:"sup"`
-	I   []Italic `xml:"i"`
-	B   []Bold   `xml:"b"`
+	Sub      []Sub    `xml:"sub"`
+	Sup      []Sup    `xml:"sup"`
+	I        []Italic `xml:"i"`
+	B        []Bold   `xml:"b"`
+	InnerXML string   `xml:",innerxml"`
 }
 
 // Sub represents subscript text
@@ -131,6 +314,7 @@ type DrawingsInfo struct {
 // Figure represents a drawing figure
 type Figure struct {
 	ID  string `xml:"id,attr"`
+	Num string `xml:"num,attr"`
 	Img Image  `xml:"img"`
 }
 
@@ -246,10 +430,26 @@ func flattenElementText(d *xml.Decoder) (string, error) {
 			if depth == 0 {
 				return buf.String(), nil
 			}
+			// Table cells and list items carry no whitespace of their own
+			// between siblings; separate them so "anode</entry><entry>10"
+			// does not run together. Inline markup (b, i, sub) is left joined.
+			if blockTextElements[t.Name.Local] {
+				buf.WriteByte(' ')
+			}
 		}
 	}
 }
 
+// blockTextElements are elements whose text is a separate unit from that of
+// their siblings when an element is flattened to plain text.
+var blockTextElements = map[string]bool{
+	"entry": true,
+	"row":   true,
+	"li":    true,
+	"p":     true,
+	"br":    true,
+}
+
 // Text represents simple text with language attribute
 type Text struct {
 	ID   string `xml:"id,attr"`
@@ -296,6 +496,302 @@ func (d *XMLDocument) GetTitle() string {
 	return strings.TrimSpace(bib.InventionTitle[0].Text)
 }
 
+// bibliography returns the bibliographic data of the grant or application.
+func (d *XMLDocument) bibliography() *Bibliography {
+	if d == nil {
+		return nil
+	}
+	switch d.GetDocumentType() {
+	case DocumentTypeGrant:
+		return d.Grant.Bibliography
+	case DocumentTypeApplication:
+		return d.Application.Bibliography
+	default:
+		return nil
+	}
+}
+
+// parties returns whichever party block the document's DTD uses.
+func (b *Bibliography) parties() *Parties {
+	if b == nil {
+		return nil
+	}
+	if b.USParties != nil {
+		return b.USParties
+	}
+	return b.Parties
+}
+
+// GetInventors returns the inventors named in the bibliography, as the same
+// Inventor type GetPatentMetaData uses: first and last name, the full name
+// in InventorNameText, and the residence as the country code and a single
+// address (city, state in GeographicRegionCode, country). Documents under
+// the older DTDs that list inventors only as applicants with app-type
+// "applicant-inventor" are handled.
+func (d *XMLDocument) GetInventors() []Inventor {
+	p := d.bibliography().parties()
+	if p == nil {
+		return nil
+	}
+	entries := p.Inventors
+	if len(entries) == 0 {
+		for _, a := range append(append([]Party(nil), p.USApplicants...), p.Applicants...) {
+			if a.AppType == "applicant-inventor" {
+				entries = append(entries, a)
+			}
+		}
+	}
+	var inventors []Inventor
+	for _, e := range entries {
+		ab := e.AddressBook
+		first, last := normalizeSpace(ab.FirstName), normalizeSpace(ab.LastName)
+		inv := Inventor{
+			InventorNameText: strings.TrimSpace(first + " " + last),
+			FirstName:        first,
+			LastName:         last,
+			CountryCode:      strings.TrimSpace(ab.Address.Country),
+		}
+		if addr := ab.Address; addr != (PartyAddress{}) {
+			inv.CorrespondenceAddressBag = []CorrespondenceAddress{{
+				CityName:             strings.TrimSpace(addr.City),
+				GeographicRegionCode: strings.TrimSpace(addr.State),
+				CountryCode:          strings.TrimSpace(addr.Country),
+			}}
+		}
+		inventors = append(inventors, inv)
+	}
+	return inventors
+}
+
+// GetAssignees returns the assignees named in the bibliography as the same
+// Assignee type the assignment endpoint uses, with the state in
+// GeographicRegion and the country code in CountryName. Individuals are
+// named "first last".
+//
+// The <assignees> block is used when present. Documents without one -
+// applications in particular - name the assignee among the applicants
+// instead: us-applicant entries with applicant-authority-category
+// "assignee", or, where the DTD predates that attribute, applicant entries
+// that are organizations.
+func (d *XMLDocument) GetAssignees() []Assignee {
+	b := d.bibliography()
+	if b == nil {
+		return nil
+	}
+	entries := b.Assignees
+	if len(entries) == 0 {
+		if p := b.parties(); p != nil {
+			for _, a := range append(append([]Party(nil), p.USApplicants...), p.Applicants...) {
+				isOrg := a.AddressBook.OrgName != "" || a.OrgName != ""
+				if a.AuthorityCategory == "assignee" || (a.AuthorityCategory == "" && isOrg) {
+					entries = append(entries, a)
+				}
+			}
+		}
+	}
+	var assignees []Assignee
+	for _, e := range entries {
+		ab := e.AddressBook
+		if ab.OrgName == "" && ab.LastName == "" {
+			ab.OrgName = e.OrgName
+		}
+		addr := ab.Address
+		if addr == (PartyAddress{}) {
+			addr = e.Address
+		}
+		name := normalizeSpace(ab.OrgName)
+		if name == "" {
+			name = strings.TrimSpace(normalizeSpace(ab.FirstName) + " " + normalizeSpace(ab.LastName))
+		}
+		assignees = append(assignees, Assignee{
+			Name:             name,
+			City:             strings.TrimSpace(addr.City),
+			GeographicRegion: strings.TrimSpace(addr.State),
+			PostalCode:       strings.TrimSpace(addr.Postcode),
+			CountryName:      strings.TrimSpace(addr.Country),
+		})
+	}
+	return assignees
+}
+
+// GetCPCClassifications returns the document's CPC symbols, main first, in
+// the padded form the JSON API uses ("A61L   2/22"; see FormatCPC).
+func (d *XMLDocument) GetCPCClassifications() []string {
+	b := d.bibliography()
+	if b == nil || b.ClassificationsCPC == nil {
+		return nil
+	}
+	var symbols []string
+	for _, c := range append(append([]CPCClassification(nil), b.ClassificationsCPC.Main...), b.ClassificationsCPC.Further...) {
+		if symbol := FormatCPC(c.Section, c.Class, c.Subclass, c.MainGroup, c.Subgroup); symbol != "" {
+			symbols = append(symbols, symbol)
+		}
+	}
+	return symbols
+}
+
+// GetUSPCClassifications returns the document's USPC classifications, main
+// first, as class/subclass ("705/7", "424/185.1", "D14/138"). Documents
+// issued after USPC was retired have none.
+func (d *XMLDocument) GetUSPCClassifications() []string {
+	b := d.bibliography()
+	if b == nil || b.ClassificationNational == nil {
+		return nil
+	}
+	var symbols []string
+	for _, raw := range append([]string{b.ClassificationNational.Main}, b.ClassificationNational.Further...) {
+		if symbol := formatUSPC(raw); symbol != "" {
+			symbols = append(symbols, symbol)
+		}
+	}
+	return symbols
+}
+
+// CitedReference is one entry of GetCitations. Patent citations fill
+// Country, DocNumber, Kind, Name and Date; non-patent literature fills
+// NPLText.
+type CitedReference struct {
+	Country   string
+	DocNumber string
+	Kind      string
+	Name      string
+	Date      string // YYYYMMDD
+	NPLText   string
+	Category  string // "cited by examiner", "cited by applicant", ...
+}
+
+// IsPatent reports whether the reference is a patent document rather than
+// non-patent literature.
+func (r CitedReference) IsPatent() bool {
+	return r.DocNumber != ""
+}
+
+// CitedByExaminer reports whether the examiner, rather than the applicant
+// or a third party, cited the reference.
+func (r CitedReference) CitedByExaminer() bool {
+	return strings.Contains(strings.ToLower(r.Category), "examiner")
+}
+
+// GetCitations returns the references cited on a grant, patent and
+// non-patent, in document order. Applications carry no citation list and
+// return nil.
+func (d *XMLDocument) GetCitations() []CitedReference {
+	b := d.bibliography()
+	if b == nil {
+		return nil
+	}
+	refs := b.USReferencesCited
+	if refs == nil {
+		refs = b.ReferencesCited
+	}
+	if refs == nil {
+		return nil
+	}
+	var cited []CitedReference
+	for _, c := range append(append([]Citation(nil), refs.USCitations...), refs.Citations...) {
+		r := CitedReference{Category: normalizeSpace(c.Category)}
+		switch {
+		case c.PatCit != nil:
+			id := c.PatCit.DocumentID
+			r.Country = strings.TrimSpace(id.Country)
+			r.DocNumber = strings.TrimSpace(id.DocNumber)
+			r.Kind = strings.TrimSpace(id.Kind)
+			r.Name = normalizeSpace(id.Name)
+			r.Date = strings.TrimSpace(id.Date)
+		case c.NPLCit != nil:
+			r.NPLText = normalizeSpace(c.NPLCit.OtherCit.Text)
+		default:
+			continue
+		}
+		cited = append(cited, r)
+	}
+	return cited
+}
+
+// DocumentLabel returns a display label built from the document's
+// publication-reference kind code, e.g. "B2 Grant" or "A1 Publication".
+func (d *XMLDocument) DocumentLabel() string {
+	stage := DocumentStageUnknown
+	switch d.GetDocumentType() {
+	case DocumentTypeGrant:
+		stage = DocumentStageGrant
+	case DocumentTypeApplication:
+		stage = DocumentStagePublication
+	}
+	kind := ""
+	if bib := d.bibliography(); bib != nil && bib.PublicationReference != nil {
+		kind = bib.PublicationReference.Kind
+	}
+	return DocumentLabel(stage, kind)
+}
+
+// GetPublicationNumber returns the document number from the
+// publication-reference: the patent number of a grant, or the publication
+// number of a pre-grant publication (e.g. "20210210819").
+func (d *XMLDocument) GetPublicationNumber() string {
+	if bib := d.bibliography(); bib != nil && bib.PublicationReference != nil {
+		return strings.TrimSpace(bib.PublicationReference.DocNumber)
+	}
+	return ""
+}
+
+// GetApplicationNumber returns the application number from the
+// application-reference, e.g. "17248024".
+func (d *XMLDocument) GetApplicationNumber() string {
+	if bib := d.bibliography(); bib != nil && bib.ApplicationReference != nil {
+		return strings.TrimSpace(bib.ApplicationReference.DocNumber)
+	}
+	return ""
+}
+
+// GetKindCode returns the publication-reference kind code, e.g. "B2" or
+// "A1".
+func (d *XMLDocument) GetKindCode() string {
+	if bib := d.bibliography(); bib != nil && bib.PublicationReference != nil {
+		return strings.TrimSpace(bib.PublicationReference.Kind)
+	}
+	return ""
+}
+
+// xmlDateLayout is the YYYYMMDD form of dates in the full-text XML.
+const xmlDateLayout = "20060102"
+
+// GetPublicationDate returns the date the document was published: the
+// issue date of a grant, or the publication date of a pre-grant
+// publication. It is read from the date-publ attribute, falling back to
+// the publication-reference date.
+func (d *XMLDocument) GetPublicationDate() (time.Time, error) {
+	var raw string
+	switch d.GetDocumentType() {
+	case DocumentTypeGrant:
+		raw = d.Grant.DatePubl
+	case DocumentTypeApplication:
+		raw = d.Application.DatePubl
+	}
+	if strings.TrimSpace(raw) == "" {
+		if bib := d.bibliography(); bib != nil && bib.PublicationReference != nil {
+			raw = bib.PublicationReference.Date
+		}
+	}
+	if strings.TrimSpace(raw) == "" {
+		return time.Time{}, fmt.Errorf("no publication date in document")
+	}
+	t, err := time.Parse(xmlDateLayout, strings.TrimSpace(raw))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid publication date %q: %w", raw, err)
+	}
+	return t, nil
+}
+
+// GetGrantDate returns the issue date of a grant. A pre-grant publication
+// has none and returns an error.
+func (d *XMLDocument) GetGrantDate() (time.Time, error) {
+	if d.GetDocumentType() != DocumentTypeGrant {
+		return time.Time{}, fmt.Errorf("document is not a grant")
+	}
+	return d.GetPublicationDate()
+}
+
 // GetAbstract returns the abstract section
 func (d *XMLDocument) GetAbstract() *Abstract {
 	switch d.GetDocumentType() {
@@ -332,6 +828,37 @@ func (d *XMLDocument) GetClaims() *Claims {
 	}
 }
 
+// GetFigures returns the drawing sheets in document order, each with its
+// figure ID and num (e.g. "Fig-EMI-D00001", "00001") and the image it
+// references. It returns nil when the document has no drawings.
+func (d *XMLDocument) GetFigures() []Figure {
+	var drawings *DrawingsInfo
+	switch d.GetDocumentType() {
+	case DocumentTypeGrant:
+		drawings = d.Grant.DrawingsInfo
+	case DocumentTypeApplication:
+		drawings = d.Application.DrawingsInfo
+	}
+	if drawings == nil {
+		return nil
+	}
+	return drawings.Figures
+}
+
+// GetFigureImages returns the image of each figure that names a file, in
+// document order, e.g. File "US11646472-20230509-D00001.TIF" with Alt
+// "embedded image". The file names match the drawing sheets (D00001, ...)
+// of the PDF drawings on the documents endpoint.
+func (d *XMLDocument) GetFigureImages() []Image {
+	var out []Image
+	for _, f := range d.GetFigures() {
+		if f.Img.File != "" {
+			out = append(out, f.Img)
+		}
+	}
+	return out
+}
+
 // ExtractAbstractText extracts full text from the abstract
 func (a *Abstract) ExtractAbstractText() string {
 	if a == nil {
@@ -381,13 +908,102 @@ func (d *Description) ExtractDescriptionText() string {
 	return strings.TrimSpace(builder.String())
 }
 
-// extractParagraphText extracts text from a paragraph, handling nested elements
+// DrawingDescriptions returns the figure descriptions from the brief
+// description of the drawings, one whitespace-normalized string per paragraph
+// (e.g. "FIG. 1 is a schematic illustration of ..."). It returns nil when the
+// description has no such section.
+func (d *Description) DrawingDescriptions() []string {
+	if d == nil || d.DescriptionOfDrawings == nil {
+		return nil
+	}
+	var out []string
+	for _, p := range d.DescriptionOfDrawings.Paragraphs {
+		if text := normalizeSpace(p.Text); text != "" {
+			out = append(out, text)
+		}
+	}
+	return out
+}
+
+// TextSegment is one citable unit of a document's full text, as returned by
+// FullTextAnnotated.
+type TextSegment struct {
+	// Section is "abstract", "description", or "claims".
+	Section string
+	// ID locates the segment within its section: the paragraph num for
+	// abstract and description paragraphs ("0001", the [0001] printed in the
+	// patent), the claim number for claims ("1").
+	ID   string
+	Text string
+}
+
+// FullTextAnnotated returns the abstract, description, and claims as one
+// segment per paragraph or claim, in document order, so that text split for
+// indexing can still be cited as "[0012]" or "claim 3". Description
+// paragraphs include the brief description of the drawings, placed by
+// paragraph number; headings are left out. Empty paragraphs are skipped.
+func (d *XMLDocument) FullTextAnnotated() []TextSegment {
+	var segments []TextSegment
+	if abstract := d.GetAbstract(); abstract != nil {
+		for _, p := range abstract.Paragraphs {
+			if text := extractParagraphText(&p); text != "" {
+				segments = append(segments, TextSegment{Section: "abstract", ID: p.Num, Text: text})
+			}
+		}
+	}
+
+	if desc := d.GetDescription(); desc != nil {
+		var paragraphs []TextSegment
+		for _, p := range desc.Paragraphs {
+			if text := extractParagraphText(&p); text != "" {
+				paragraphs = append(paragraphs, TextSegment{Section: "description", ID: p.Num, Text: text})
+			}
+		}
+		if desc.DescriptionOfDrawings != nil {
+			for _, p := range desc.DescriptionOfDrawings.Paragraphs {
+				if text := normalizeSpace(p.Text); text != "" {
+					paragraphs = append(paragraphs, TextSegment{Section: "description", ID: p.Num, Text: text})
+				}
+			}
+			// Paragraph nums are zero-padded ("0007"), so they sort as strings.
+			slices.SortStableFunc(paragraphs, func(a, b TextSegment) int {
+				return strings.Compare(a.ID, b.ID)
+			})
+		}
+		segments = append(segments, paragraphs...)
+	}
+
+	if claims := d.GetClaims(); claims != nil {
+		for i, claim := range claims.ClaimList {
+			if text := claim.ExtractClaimText(); text != "" {
+				segments = append(segments, TextSegment{Section: "claims", ID: strconv.Itoa(claim.number(i)), Text: text})
+			}
+		}
+	}
+	return segments
+}
+
+// extractParagraphText extracts text from a paragraph, handling nested elements:
+// the text of <sub>, <sup>, <i>, <b> and any other inline markup stays in place,
+// so "H<sub>2</sub>O" reads "H2O". A paragraph built without InnerXML (not
+// parsed from XML) falls back to its chardata.
 func extractParagraphText(p *Paragraph) string {
 	if p == nil {
 		return ""
 	}
+	if p.InnerXML == "" {
+		return strings.TrimSpace(p.Text)
+	}
 
-	return strings.TrimSpace(p.Text)
+	d := xml.NewDecoder(strings.NewReader("<p>" + p.InnerXML + "</p>"))
+	if _, err := d.Token(); err != nil {
+		return strings.TrimSpace(p.Text)
+	}
+	text, err := flattenElementText(d)
+	if err != nil {
+		return strings.TrimSpace(p.Text)
+	}
+	return strings.TrimSpace(text)
 }
 
 // ExtractClaimText returns the full text of a claim with internal runs of whitespace
@@ -439,58 +1055,245 @@ func (c *Claims) ExtractAllClaimsTextFormatted() string {
 
 	var builder strings.Builder
 	for i, claim := range c.ClaimList {
-		claimNum := i + 1
-		if claim.Num != "" {
-			claimNum = 0 // Use the claim number from XML if available
-			// Try to parse it
-			if _, err := fmt.Sscanf(claim.Num, "%d", &claimNum); err != nil {
-				claimNum = i + 1
-			}
-		}
-
 		if i > 0 {
 			builder.WriteString("\n\n")
 		}
 
-		fmt.Fprintf(&builder, "CLAIM %d:\n%s", claimNum, claim.ExtractClaimText())
+		fmt.Fprintf(&builder, "CLAIM %d:\n%s", claim.number(i), claim.ExtractClaimText())
 	}
 
 	return builder.String()
 }
 
+// number returns the claim number from the num attribute ("00004" is 4), or
+// index+1 for a claim at that position without a usable one.
+func (c *Claim) number(index int) int {
+	n := 0
+	if _, err := fmt.Sscanf(c.Num, "%d", &n); err != nil || n <= 0 {
+		return index + 1
+	}
+	return n
+}
+
+// claimRefPattern matches a reference to one or more claims as USPTO claims
+// phrase it: "claim 1", "claims 2-4", "claims 2 to 4", "claims 1, 3 or 5",
+// "any one of claims 1 through 6", "claim 1 and claim 7".
+var claimRefPattern = regexp.MustCompile(`(?i)\bclaims?\s+\d+(?:\s*(?:,|-|–|to|through|or|and|and/or|,\s*or|,\s*and)\s*(?:claims?\s+)?\d+)*`)
+
+// claimRefToken splits a claimRefPattern match into numbers and the range
+// operators between them.
+var claimRefToken = regexp.MustCompile(`(?i)\d+|-|–|\bto\b|\bthrough\b`)
+
+// GetDependencies returns, for each dependent claim, the claims it refers
+// to, read from references such as "The system of claim 1" or "The method
+// of any of claims 2-4" in the claim text. Ranges are expanded; a claim
+// citing several parents lists them all, ascending. Only references to
+// earlier claims count, since a claim can depend on nothing else.
+// Independent claims have no entry.
+func (c *Claims) GetDependencies() map[int][]int {
+	deps := map[int][]int{}
+	if c == nil {
+		return deps
+	}
+	for i, claim := range c.ClaimList {
+		self := claim.number(i)
+		parents := map[int]bool{}
+		for _, ref := range claimRefPattern.FindAllString(claim.ExtractClaimText(), -1) {
+			prev, inRange := 0, false
+			for _, tok := range claimRefToken.FindAllString(ref, -1) {
+				n, err := strconv.Atoi(tok)
+				if err != nil {
+					inRange = prev > 0
+					continue
+				}
+				lo := n
+				if inRange && n > prev {
+					lo = prev + 1
+				}
+				for m := lo; m <= n; m++ {
+					if m < self {
+						parents[m] = true
+					}
+				}
+				prev, inRange = n, false
+			}
+		}
+		if len(parents) > 0 {
+			deps[self] = slices.Sorted(maps.Keys(parents))
+		}
+	}
+	return deps
+}
+
+// Count returns the number of claims and how many of them are independent
+// and dependent, using the same "claim N" back-references as
+// GetDependencies. A multiple-dependent claim ("of claim 1 or 2") counts once,
+// as dependent; fee calculations that weight it by its references should
+// use GetDependencies instead.
+func (c *Claims) Count() (total, independent, dependent int) {
+	if c == nil {
+		return 0, 0, 0
+	}
+	total = len(c.ClaimList)
+	dependent = len(c.GetDependencies())
+	return total, total - dependent, dependent
+}
+
+// IndependentClaims returns the numbers of the claims that refer to no
+// earlier claim, in document order.
+func (c *Claims) IndependentClaims() []int {
+	if c == nil {
+		return nil
+	}
+	deps := c.GetDependencies()
+	var independent []int
+	for i, claim := range c.ClaimList {
+		if n := claim.number(i); len(deps[n]) == 0 {
+			independent = append(independent, n)
+		}
+	}
+	return independent
+}
+
+// TransitionType classifies the transitional phrase that joins a claim's
+// preamble to its body, which sets how far the claim reaches beyond the
+// recited elements.
+type TransitionType int
+
+// Transition type values.
+const (
+	TransitionUnknown                 TransitionType = iota
+	TransitionComprising                             // open: other elements may be present
+	TransitionConsistingOf                           // closed: only the recited elements
+	TransitionConsistingEssentiallyOf                // partially open: nothing that changes the basic character
+)
+
+// String returns the transitional phrase, e.g. "consisting of".
+func (t TransitionType) String() string {
+	switch t {
+	case TransitionComprising:
+		return "comprising"
+	case TransitionConsistingOf:
+		return "consisting of"
+	case TransitionConsistingEssentiallyOf:
+		return "consisting essentially of"
+	default:
+		return "unknown"
+	}
+}
+
+// transitionPattern matches the transitional phrases in their usual verb
+// forms; the group that matched gives the TransitionType.
+var transitionPattern = regexp.MustCompile(`(?i)\b(?:(consist(?:s|ing)\s+essentially\s+of)|(consist(?:s|ing)\s+of)|(compris(?:es|ing|ed\s+of)))\b`)
+
+// TransitionType returns the claim's transitional phrase. The first phrase
+// in the claim text is the one that counts, so "consisting of A and B,
+// wherein A comprises C" is closed. A claim with none of the phrases, such
+// as one using "having" or "including", returns TransitionUnknown.
+func (c *Claim) TransitionType() TransitionType {
+	m := transitionPattern.FindStringSubmatchIndex(c.ExtractClaimText())
+	switch {
+	case m == nil:
+		return TransitionUnknown
+	case m[2] >= 0:
+		return TransitionConsistingEssentiallyOf
+	case m[4] >= 0:
+		return TransitionConsistingOf
+	default:
+		return TransitionComprising
+	}
+}
+
 // DownloadXML downloads and parses an XML document from a given URL
 // If you know the document type, use DownloadXMLWithType for better performance
 func (c *Client) DownloadXML(ctx context.Context, url string) (*XMLDocument, error) {
 	return c.DownloadXMLWithType(ctx, url, DocumentTypeUnknown)
 }
 
-// DownloadXMLWithType downloads and parses an XML document with a known type hint
-func (c *Client) DownloadXMLWithType(ctx context.Context, url string, expectedType DocumentType) (*XMLDocument, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+// apiKeyAllowedFor reports whether the X-API-Key may be sent to u. XML URLs
+// come from fileLocationURI in the patent metadata rather than from the
+// caller, so the key is only attached for https uspto.gov URLs and for URLs
+// on the host and scheme of the configured ODP, fallback and Office Action
+// base URLs. Other URLs are still fetched, just without credentials.
+func (c *Client) apiKeyAllowedFor(u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	if u.Scheme == "https" && (host == "uspto.gov" || strings.HasSuffix(host, ".uspto.gov")) {
+		return true
 	}
-
-	req.Header.Set("User-Agent", c.config.UserAgent)
-	if c.config.APIKey != "" {
-		req.Header.Set("X-API-Key", c.config.APIKey)
+	for _, base := range []string{c.config.BaseURL, c.config.FallbackBaseURL, c.config.OABaseURL} {
+		b, err := url.Parse(base)
+		if err == nil && b.Host != "" && strings.EqualFold(b.Scheme, u.Scheme) && strings.EqualFold(b.Host, u.Host) {
+			return true
+		}
 	}
+	return false
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("downloading XML: %w", err)
+// xmlHTTPClient is the client's HTTP client with the API key check applied
+// to every redirect hop as well: Go forwards custom headers such as
+// X-API-Key to wherever a redirect points, so a trusted URL that redirects
+// elsewhere would otherwise hand the key on.
+func (c *Client) xmlHTTPClient() *http.Client {
+	hc := *c.httpClient
+	hc.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		if !c.apiKeyAllowedFor(req.URL) {
+			req.Header.Del("X-API-Key")
+		}
+		return nil
 	}
-	defer drainClose(resp.Body)
+	return &hc
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("download failed with status %d", resp.StatusCode)
-	}
+// DownloadXMLWithType downloads and parses an XML document with a known type hint
+func (c *Client) DownloadXMLWithType(ctx context.Context, url string, expectedType DocumentType) (*XMLDocument, error) {
+	return c.DownloadXMLWithProgress(ctx, url, expectedType, nil)
+}
 
-	xmlData, err := io.ReadAll(resp.Body)
+// DownloadXMLWithProgress is DownloadXMLWithType reporting download progress
+// like DownloadBulkFileWithProgress; bytesTotal is -1 when the server sends
+// no Content-Length. The document is read into memory before parsing, so a
+// failed attempt is retried from the start like any other request, and
+// progress then restarts from zero.
+func (c *Client) DownloadXMLWithProgress(ctx context.Context, url string, expectedType DocumentType, progress func(bytesComplete int64, bytesTotal int64)) (*XMLDocument, error) {
+	var xmlData []byte
+	httpClient := c.xmlHTTPClient()
+	err := c.retryLoop(ctx, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return fmt.Errorf("creating request: %w", err)
+		}
+		req.Header.Set("User-Agent", c.config.UserAgent)
+		if c.config.APIKey != "" && c.apiKeyAllowedFor(req.URL) {
+			req.Header.Set("X-API-Key", c.config.APIKey)
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("downloading XML: %w", err)
+		}
+		defer drainClose(resp.Body)
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			// Read a bounded prefix of the error body for the APIError.
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+			return checkResponseStatus(resp.StatusCode, body, resp)
+		}
+
+		var src io.Reader = resp.Body
+		if progress != nil {
+			src = &progressReader{r: resp.Body, total: resp.ContentLength, fn: progress}
+		}
+		xmlData, err = io.ReadAll(src)
+		if err != nil {
+			return fmt.Errorf("reading XML data: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("reading XML data: %w", err)
+		return nil, err
 	}
-
 	return ParseXMLWithType(xmlData, expectedType)
 }
 
@@ -499,6 +1302,23 @@ func ParseXML(data []byte) (*XMLDocument, error) {
 	return ParseXMLWithType(data, DocumentTypeUnknown)
 }
 
+// LoadXMLFile reads and parses a single grant or application XML file from
+// disk, such as one written by SplitBulkXMLFile, auto-detecting the document
+// type. A missing file yields an error matching fs.ErrNotExist; a file that is
+// not a us-patent-grant or us-patent-application document yields an error
+// naming the path.
+func LoadXMLFile(path string) (*XMLDocument, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading patent XML file: %w", err)
+	}
+	doc, err := ParseXML(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return doc, nil
+}
+
 // ParseGrantXML parses patent grant XML data (us-patent-grant)
 func ParseGrantXML(data []byte) (*XMLDocument, error) {
 	return ParseXMLWithType(data, DocumentTypeGrant)
@@ -566,28 +1386,75 @@ func (c *Client) GetXMLURLForApplication(ctx context.Context, patentNumber strin
 	if err != nil {
 		return "", DocumentTypeUnknown, fmt.Errorf("failed to get patent data: %w", err)
 	}
+	return xmlURLFromPatentData(resp)
+}
 
-	if resp.PatentFileWrapperDataBag == nil || len(*resp.PatentFileWrapperDataBag) == 0 {
-		return "", DocumentTypeUnknown, fmt.Errorf("no patent data found")
+// GetGrantXMLURL returns the URL of a patent's grant full-text XML, or an
+// error if it has not been granted. Use it with GetApplicationXMLURL to
+// compare the as-published and as-granted text; GetXMLURLForApplication
+// returns only the grant once one exists.
+func (c *Client) GetGrantXMLURL(ctx context.Context, patentNumber string) (string, error) {
+	resp, err := c.GetPatent(ctx, patentNumber)
+	if err != nil {
+		return "", fmt.Errorf("failed to get patent data: %w", err)
+	}
+	if uri := grantXMLURL(resp); uri != "" {
+		return uri, nil
 	}
+	return "", fmt.Errorf("no grant XML URL found in patent data")
+}
 
-	patentData := (*resp.PatentFileWrapperDataBag)[0]
+// GetApplicationXMLURL returns the URL of the pre-grant publication
+// full-text XML of a patent, granted or not, or an error if the
+// application was never published.
+func (c *Client) GetApplicationXMLURL(ctx context.Context, patentNumber string) (string, error) {
+	resp, err := c.GetPatent(ctx, patentNumber)
+	if err != nil {
+		return "", fmt.Errorf("failed to get patent data: %w", err)
+	}
+	if uri := pgpubXMLURL(resp); uri != "" {
+		return uri, nil
+	}
+	return "", fmt.Errorf("no pre-grant publication XML URL found in patent data")
+}
 
-	// Try grant XML first (granted patents)
-	if patentData.GrantDocumentMetaData != nil && patentData.GrantDocumentMetaData.FileLocationURI != nil {
-		if uri := *patentData.GrantDocumentMetaData.FileLocationURI; uri != "" {
-			return uri, DocumentTypeGrant, nil
-		}
+// xmlURLFromPatentData picks the full-text XML URL out of an already fetched
+// patent record, preferring the grant over the pre-grant publication.
+func xmlURLFromPatentData(resp *generated.PatentDataResponse) (string, DocumentType, error) {
+	if resp == nil || resp.PatentFileWrapperDataBag == nil || len(*resp.PatentFileWrapperDataBag) == 0 {
+		return "", DocumentTypeUnknown, fmt.Errorf("no patent data found")
+	}
+	if uri := grantXMLURL(resp); uri != "" {
+		return uri, DocumentTypeGrant, nil
 	}
+	if uri := pgpubXMLURL(resp); uri != "" {
+		return uri, DocumentTypeApplication, nil
+	}
+	return "", DocumentTypeUnknown, fmt.Errorf("no XML URL found in patent data")
+}
 
-	// Try pre-grant publication XML (published applications)
-	if patentData.PgpubDocumentMetaData != nil && patentData.PgpubDocumentMetaData.FileLocationURI != nil {
-		if uri := *patentData.PgpubDocumentMetaData.FileLocationURI; uri != "" {
-			return uri, DocumentTypeApplication, nil
-		}
+// grantXMLURL returns the grant XML fileLocationURI of the first record in
+// resp, or "".
+func grantXMLURL(resp *generated.PatentDataResponse) string {
+	if resp == nil || resp.PatentFileWrapperDataBag == nil || len(*resp.PatentFileWrapperDataBag) == 0 {
+		return ""
+	}
+	if m := (*resp.PatentFileWrapperDataBag)[0].GrantDocumentMetaData; m != nil {
+		return derefStr(m.FileLocationURI)
 	}
+	return ""
+}
 
-	return "", DocumentTypeUnknown, fmt.Errorf("no XML URL found in patent data")
+// pgpubXMLURL returns the pre-grant publication XML fileLocationURI of the
+// first record in resp, or "".
+func pgpubXMLURL(resp *generated.PatentDataResponse) string {
+	if resp == nil || resp.PatentFileWrapperDataBag == nil || len(*resp.PatentFileWrapperDataBag) == 0 {
+		return ""
+	}
+	if m := (*resp.PatentFileWrapperDataBag)[0].PgpubDocumentMetaData; m != nil {
+		return derefStr(m.FileLocationURI)
+	}
+	return ""
 }
 
 // GetPatentXML retrieves and parses the XML document for a patent
@@ -600,3 +1467,26 @@ func (c *Client) GetPatentXML(ctx context.Context, patentNumber string) (*XMLDoc
 
 	return c.DownloadXMLWithType(ctx, xmlURL, docType)
 }
+
+// GetPatentWithXML retrieves the patent record and its parsed full-text XML.
+// The XML URL is taken from the fetched record, so this costs one patent
+// lookup plus the XML download (GetPatent followed by GetPatentXML would
+// look the patent up twice). When the record is found but has no XML or the
+// download fails, the record is returned together with the error.
+func (c *Client) GetPatentWithXML(ctx context.Context, patentNumber string) (*generated.PatentDataResponse, *XMLDocument, error) {
+	resp, err := c.GetPatent(ctx, patentNumber)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get patent data: %w", err)
+	}
+
+	xmlURL, docType, err := xmlURLFromPatentData(resp)
+	if err != nil {
+		return resp, nil, err
+	}
+
+	doc, err := c.DownloadXMLWithType(ctx, xmlURL, docType)
+	if err != nil {
+		return resp, nil, err
+	}
+	return resp, doc, nil
+}
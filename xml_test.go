@@ -1,8 +1,24 @@
 package odp
 
 import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/patent-dev/uspto-odp/generated"
 )
 
 // Sample patent grant XML (simplified but representative of ICE DTD 4.7 structure)
@@ -25,7 +41,110 @@ const sampleGrantXML = `<?xml version="1.0" encoding="UTF-8"?>
         <date>20210101</date>
       </document-id>
     </application-reference>
+    <classification-national>
+      <country>US</country>
+      <main-classification>705  7</main-classification>
+      <further-classification>4241851</further-classification>
+    </classification-national>
+    <classifications-cpc>
+      <main-cpc>
+        <classification-cpc>
+          <cpc-version-indicator><date>20130101</date></cpc-version-indicator>
+          <section>G</section>
+          <class>06</class>
+          <subclass>N</subclass>
+          <main-group>3</main-group>
+          <subgroup>08</subgroup>
+          <symbol-position>F</symbol-position>
+          <classification-value>I</classification-value>
+        </classification-cpc>
+      </main-cpc>
+      <further-cpc>
+        <classification-cpc>
+          <cpc-version-indicator><date>20130101</date></cpc-version-indicator>
+          <section>A</section>
+          <class>61</class>
+          <subclass>L</subclass>
+          <main-group>2101</main-group>
+          <subgroup>32</subgroup>
+          <symbol-position>L</symbol-position>
+          <classification-value>A</classification-value>
+        </classification-cpc>
+      </further-cpc>
+    </classifications-cpc>
+    <us-references-cited>
+      <us-citation>
+        <patcit num="00001">
+          <document-id>
+            <country>US</country>
+            <doc-number>9876543</doc-number>
+            <kind>B1</kind>
+            <name>Lee et al.</name>
+            <date>20180102</date>
+          </document-id>
+        </patcit>
+        <category>cited by examiner</category>
+        <classification-cpc-text>G06N 3/08</classification-cpc-text>
+      </us-citation>
+      <us-citation>
+        <nplcit num="00002">
+          <othercit>Goodfellow et al., <i>Deep Learning</i>, MIT Press, 2016.</othercit>
+        </nplcit>
+        <category>cited by applicant</category>
+      </us-citation>
+    </us-references-cited>
     <invention-title id="d2e43">SYSTEM AND METHOD FOR ARTIFICIAL INTELLIGENCE</invention-title>
+    <us-parties>
+      <us-applicants>
+        <us-applicant sequence="001" app-type="applicant" designation="us-only" applicant-authority-category="assignee">
+          <addressbook>
+            <orgname>Example Robotics, Inc.</orgname>
+            <address>
+              <city>Austin</city>
+              <state>TX</state>
+              <country>US</country>
+            </address>
+          </addressbook>
+          <residence><country>US</country></residence>
+        </us-applicant>
+      </us-applicants>
+      <inventors>
+        <inventor sequence="001" designation="us-only">
+          <addressbook>
+            <last-name>Smith</last-name>
+            <first-name>Jane A.</first-name>
+            <address>
+              <city>Austin</city>
+              <state>TX</state>
+              <country>US</country>
+            </address>
+          </addressbook>
+        </inventor>
+        <inventor sequence="002" designation="us-only">
+          <addressbook>
+            <last-name>Müller</last-name>
+            <first-name>Karl</first-name>
+            <address>
+              <city>Munich</city>
+              <country>DE</country>
+            </address>
+          </addressbook>
+        </inventor>
+      </inventors>
+    </us-parties>
+    <assignees>
+      <assignee>
+        <addressbook>
+          <orgname>Example Holdings LLC</orgname>
+          <role>02</role>
+          <address>
+            <city>Wilmington</city>
+            <state>DE</state>
+            <country>US</country>
+          </address>
+        </addressbook>
+      </assignee>
+    </assignees>
   </us-bibliographic-data-grant>
   <abstract id="abstract">
     <p id="p-0001" num="0001">A system for artificial intelligence processing includes a neural network architecture designed to optimize computational efficiency. The system comprises multiple layers of interconnected nodes.</p>
@@ -62,6 +181,14 @@ const sampleApplicationXML = `<?xml version="1.0" encoding="UTF-8"?>
 <!DOCTYPE us-patent-application SYSTEM "us-patent-application-v46-2022-02-17.dtd">
 <us-patent-application lang="EN" dtd-version="v4.6 2022-02-17" file="US17248024-20210101.XML" status="PRODUCTION" id="us-patent-application" country="US" date-produced="20210101" date-publ="20210701">
   <us-bibliographic-data-application>
+    <publication-reference>
+      <document-id>
+        <country>US</country>
+        <doc-number>20210210819</doc-number>
+        <kind>A1</kind>
+        <date>20210708</date>
+      </document-id>
+    </publication-reference>
     <application-reference appl-type="utility">
       <document-id>
         <country>US</country>
@@ -89,6 +216,86 @@ const sampleApplicationXML = `<?xml version="1.0" encoding="UTF-8"?>
   </claims>
 </us-patent-application>`
 
+// Bibliography under the pre-2012 grant DTD (v4.2): parties instead of
+// us-parties, and inventors listed as applicants with app-type
+// "applicant-inventor".
+const sampleLegacyPartiesGrantXML = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE us-patent-grant SYSTEM "us-patent-grant-v42-2006-08-23.dtd" [ ]>
+<us-patent-grant lang="EN" dtd-version="v4.2 2006-08-23" file="US07654321-20100202.XML" status="PRODUCTION" id="us-patent-grant" country="US" date-produced="20100118" date-publ="20100202">
+  <us-bibliographic-data-grant>
+    <publication-reference>
+      <document-id>
+        <country>US</country>
+        <doc-number>07654321</doc-number>
+        <kind>B2</kind>
+        <date>20100202</date>
+      </document-id>
+    </publication-reference>
+    <invention-title id="d0e53">Legacy widget</invention-title>
+    <parties>
+      <applicants>
+        <applicant sequence="001" app-type="applicant-inventor" designation="us-only">
+          <addressbook>
+            <last-name>Doe</last-name>
+            <first-name>John</first-name>
+            <address>
+              <city>Springfield</city>
+              <state>IL</state>
+              <country>US</country>
+            </address>
+          </addressbook>
+          <nationality><country>US</country></nationality>
+        </applicant>
+      </applicants>
+    </parties>
+    <references-cited>
+      <citation>
+        <patcit num="00001">
+          <document-id>
+            <country>US</country>
+            <doc-number>4123456</doc-number>
+            <kind>A</kind>
+            <name>Roe</name>
+            <date>19781031</date>
+          </document-id>
+        </patcit>
+        <category>cited by other</category>
+      </citation>
+    </references-cited>
+    <assignees>
+      <assignee>
+        <orgname>Acme Widget Corp.</orgname>
+        <role>02</role>
+        <address>
+          <city>Chicago</city>
+          <state>IL</state>
+          <postcode>60601</postcode>
+          <country>US</country>
+        </address>
+      </assignee>
+    </assignees>
+  </us-bibliographic-data-grant>
+</us-patent-grant>`
+
+// Grant description with a brief-description-of-drawings section, laid out the
+// way ICE emits it: the heading and figure paragraphs sit inside their own
+// <description-of-drawings> element, and figure labels are wrapped in <figref>.
+const sampleDrawingsDescriptionXML = `<?xml version="1.0" encoding="UTF-8"?>
+<us-patent-grant lang="EN" dtd-version="v4.7 2022-02-17" id="us-patent-grant" country="US">
+  <description id="description">
+    <heading id="h-0001" level="1">TECHNICAL FIELD</heading>
+    <p id="p-0001" num="0001">This invention relates to battery cells.</p>
+    <description-of-drawings>
+      <heading id="h-0002" level="1">BRIEF DESCRIPTION OF THE DRAWINGS</heading>
+      <p id="p-0002" num="0002"><figref idref="DRAWINGS">FIG. <b>1</b></figref> is a schematic illustration of a cell.</p>
+      <p id="p-0003" num="0003"><figref idref="DRAWINGS">FIGS. <b>2</b>A-C</figref> illustrate
+        tubular anode designs.</p>
+    </description-of-drawings>
+    <heading id="h-0003" level="1">DETAILED DESCRIPTION</heading>
+    <p id="p-0004" num="0004">Reference will now be made in detail to <figref idref="DRAWINGS">FIG. <b>1</b></figref>.</p>
+  </description>
+</us-patent-grant>`
+
 // Invalid XML for error testing
 const invalidXML = `<?xml version="1.0" encoding="UTF-8"?>
 <invalid-root>
@@ -216,6 +423,149 @@ func TestParseXML_Application(t *testing.T) {
 	}
 }
 
+func TestXMLDocumentLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		xml  string
+		want string
+	}{
+		{"grant", sampleGrantXML, "B2 Grant"},
+		{"pre-grant publication", sampleApplicationXML, "A1 Publication"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseXML([]byte(tt.xml))
+			if err != nil {
+				t.Fatalf("ParseXML: %v", err)
+			}
+			if got := doc.DocumentLabel(); got != tt.want {
+				t.Errorf("DocumentLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXMLDocumentNumbers(t *testing.T) {
+	tests := []struct {
+		name                   string
+		xml                    string
+		wantPub, wantApp, kind string
+	}{
+		{"grant", sampleGrantXML, "11234567", "17248024", "B2"},
+		{"pre-grant publication", sampleApplicationXML, "20210210819", "17248024", "A1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseXML([]byte(tt.xml))
+			if err != nil {
+				t.Fatalf("ParseXML: %v", err)
+			}
+			if got := doc.GetPublicationNumber(); got != tt.wantPub {
+				t.Errorf("GetPublicationNumber() = %q, want %q", got, tt.wantPub)
+			}
+			if got := doc.GetApplicationNumber(); got != tt.wantApp {
+				t.Errorf("GetApplicationNumber() = %q, want %q", got, tt.wantApp)
+			}
+			if got := doc.GetKindCode(); got != tt.kind {
+				t.Errorf("GetKindCode() = %q, want %q", got, tt.kind)
+			}
+		})
+	}
+
+	var empty *XMLDocument
+	if empty.GetPublicationNumber() != "" || empty.GetApplicationNumber() != "" || empty.GetKindCode() != "" {
+		t.Error("nil document should return empty numbers")
+	}
+}
+
+func TestXMLDocumentDates(t *testing.T) {
+	grant, err := ParseXML([]byte(sampleGrantXML))
+	if err != nil {
+		t.Fatalf("ParseXML: %v", err)
+	}
+	want := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got, err := grant.GetPublicationDate(); err != nil || !got.Equal(want) {
+		t.Errorf("grant GetPublicationDate() = %v, %v; want %v", got, err, want)
+	}
+	if got, err := grant.GetGrantDate(); err != nil || !got.Equal(want) {
+		t.Errorf("GetGrantDate() = %v, %v; want %v", got, err, want)
+	}
+
+	app, err := ParseXML([]byte(sampleApplicationXML))
+	if err != nil {
+		t.Fatalf("ParseXML: %v", err)
+	}
+	want = time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)
+	if got, err := app.GetPublicationDate(); err != nil || !got.Equal(want) {
+		t.Errorf("application GetPublicationDate() = %v, %v; want %v", got, err, want)
+	}
+	if _, err := app.GetGrantDate(); err == nil {
+		t.Error("GetGrantDate() on a pre-grant publication should fail")
+	}
+
+	// Without date-publ the publication-reference date is used.
+	app.Application.DatePubl = ""
+	want = time.Date(2021, 7, 8, 0, 0, 0, 0, time.UTC)
+	if got, err := app.GetPublicationDate(); err != nil || !got.Equal(want) {
+		t.Errorf("fallback GetPublicationDate() = %v, %v; want %v", got, err, want)
+	}
+
+	app.Application.DatePubl = "2021-07"
+	if _, err := app.GetPublicationDate(); err == nil {
+		t.Error("malformed date-publ should fail")
+	}
+}
+
+func TestXMLURLFromPatentData(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantURL  string
+		wantType DocumentType
+		wantErr  bool
+	}{
+		{
+			name:     "grant preferred",
+			body:     `{"patentFileWrapperDataBag":[{"grantDocumentMetaData":{"fileLocationURI":"https://x/g.xml"},"pgpubDocumentMetaData":{"fileLocationURI":"https://x/a.xml"}}]}`,
+			wantURL:  "https://x/g.xml",
+			wantType: DocumentTypeGrant,
+		},
+		{
+			name:     "empty grant URI falls back to pgpub",
+			body:     `{"patentFileWrapperDataBag":[{"grantDocumentMetaData":{"fileLocationURI":""},"pgpubDocumentMetaData":{"fileLocationURI":"https://x/a.xml"}}]}`,
+			wantURL:  "https://x/a.xml",
+			wantType: DocumentTypeApplication,
+		},
+		{
+			name:     "metadata without URI",
+			body:     `{"patentFileWrapperDataBag":[{"grantDocumentMetaData":{"productIdentifier":"PTGRXML"}}]}`,
+			wantType: DocumentTypeUnknown,
+			wantErr:  true,
+		},
+		{
+			name:     "empty bag",
+			body:     `{"patentFileWrapperDataBag":[]}`,
+			wantType: DocumentTypeUnknown,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp generated.PatentDataResponse
+			if err := json.Unmarshal([]byte(tt.body), &resp); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			url, docType, err := xmlURLFromPatentData(&resp)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if url != tt.wantURL || docType != tt.wantType {
+				t.Errorf("got (%q, %v), want (%q, %v)", url, docType, tt.wantURL, tt.wantType)
+			}
+		})
+	}
+}
+
 func TestParseXML_InvalidDocument(t *testing.T) {
 	doc, err := ParseXML([]byte(invalidXML))
 	if err == nil {
@@ -321,6 +671,33 @@ func TestExtractAbstractText(t *testing.T) {
 	}
 }
 
+func TestExtractParagraphText_InlineFormatting(t *testing.T) {
+	const doc = `<us-patent-grant>
+  <abstract id="abstract">
+    <p id="p-0001" num="0001">Water (H<sub>2</sub>O) at 10<sup>−3</sup> M is applied <i>in vivo</i> to the <b>anode</b>.</p>
+  </abstract>
+  <description id="description">
+    <p id="p-0002" num="0002">The compound of formula (I), C<sub>6</sub>H<sub>12</sub>O<sub>6</sub>, is dissolved &amp; stirred.</p>
+  </description>
+</us-patent-grant>`
+	var grant PatentGrant
+	if err := xml.Unmarshal([]byte(doc), &grant); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got, want := grant.Abstract.ExtractAbstractText(), "Water (H2O) at 10−3 M is applied in vivo to the anode."; got != want {
+		t.Errorf("abstract = %q, want %q", got, want)
+	}
+	if got, want := grant.Description.ExtractDescriptionText(), "The compound of formula (I), C6H12O6, is dissolved & stirred."; got != want {
+		t.Errorf("description = %q, want %q", got, want)
+	}
+
+	// A paragraph built in code has no InnerXML and keeps using Text.
+	p := Paragraph{Text: " plain text "}
+	if got := extractParagraphText(&p); got != "plain text" {
+		t.Errorf("constructed paragraph = %q, want %q", got, "plain text")
+	}
+}
+
 func TestExtractAbstractText_Nil(t *testing.T) {
 	var abstract *Abstract
 	text := abstract.ExtractAbstractText()
@@ -475,6 +852,103 @@ func TestExtractClaimText_Nested(t *testing.T) {
 	}
 }
 
+// TestExtractClaimText_InterleavedOrder checks that text after a nested
+// limitation stays after it: the wherein clause closes the claim rather than
+// being pulled up next to the preamble.
+func TestExtractClaimText_InterleavedOrder(t *testing.T) {
+	const claimXML = `<claim id="CLM-00001" num="00001">
+  <claim-text>1. A container comprising:
+    <claim-text>a housing;</claim-text>
+    <claim-text>a lid coupled to the housing by
+      <claim-text>a hinge,</claim-text>
+    the hinge having a detent;</claim-text>
+  wherein the lid seals against the housing when closed.</claim-text>
+</claim>`
+	var claim Claim
+	if err := xml.Unmarshal([]byte(claimXML), &claim); err != nil {
+		t.Fatalf("unmarshal claim: %v", err)
+	}
+	want := "1. A container comprising: a housing; a lid coupled to the housing by a hinge, the hinge having a detent; wherein the lid seals against the housing when closed."
+	if got := claim.ExtractClaimText(); got != want {
+		t.Errorf("ExtractClaimText() =\n%q\nwant\n%q", got, want)
+	}
+	if top := claim.ClaimText[0]; len(top.NestedClaims) != 2 || len(top.NestedClaims[1].NestedClaims) != 1 {
+		t.Errorf("nested structure = %+v, want two limitations, the second with one sub-limitation", top.NestedClaims)
+	}
+}
+
+func TestClaimsGetDependencies(t *testing.T) {
+	const claimsXML = `<claims id="claims">
+  <claim id="CLM-00001" num="00001"><claim-text>1. A battery cell comprising an anode.</claim-text></claim>
+  <claim id="CLM-00002" num="00002"><claim-text>2. The battery cell of <claim-ref idref="CLM-00001">claim 1</claim-ref>, wherein the anode is lithium.</claim-text></claim>
+  <claim id="CLM-00003" num="00003"><claim-text>3. The battery cell according to <claim-ref idref="CLM-00001">claim 1</claim-ref> or <claim-ref idref="CLM-00002">2</claim-ref>, further comprising a separator.</claim-text></claim>
+  <claim id="CLM-00004" num="00004"><claim-text>4. The battery cell of any one of <claim-ref idref="CLM-00001">claims 1</claim-ref>-<claim-ref idref="CLM-00003">3</claim-ref>, wherein the separator is ceramic.</claim-text></claim>
+  <claim id="CLM-00005" num="00005"><claim-text>5. A method of making a battery cell, comprising:
+    <claim-text>forming an anode; and</claim-text>
+    <claim-text>coating the anode to a thickness of 10 to 20 μm.</claim-text></claim-text></claim>
+  <claim id="CLM-00006" num="00006"><claim-text>6. The method as claimed in claims 2 through 3 and claim 5, wherein the coating is applied to claim 9 of the drawings.</claim-text></claim>
+</claims>`
+	var claims Claims
+	if err := xml.Unmarshal([]byte(claimsXML), &claims); err != nil {
+		t.Fatalf("unmarshal claims: %v", err)
+	}
+	want := map[int][]int{
+		2: {1},
+		3: {1, 2},
+		4: {1, 2, 3},
+		// The stray "claim 9" is not an earlier claim and is ignored.
+		6: {2, 3, 5},
+	}
+	if got := claims.GetDependencies(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetDependencies() = %v, want %v", got, want)
+	}
+	if got, want := claims.IndependentClaims(), []int{1, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("IndependentClaims() = %v, want %v", got, want)
+	}
+
+	// Claims 3, 4 and 6 are multiple-dependent and still count once each.
+	if total, indep, dep := claims.Count(); total != 6 || indep != 2 || dep != 4 {
+		t.Errorf("Count() = %d, %d, %d; want 6, 2, 4", total, indep, dep)
+	}
+
+	var nilClaims *Claims
+	if len(nilClaims.GetDependencies()) != 0 || nilClaims.IndependentClaims() != nil {
+		t.Error("nil claims should have no dependencies or independent claims")
+	}
+	if total, _, _ := nilClaims.Count(); total != 0 {
+		t.Errorf("nil Count() total = %d, want 0", total)
+	}
+}
+
+// sampleFigrefClaimXML is a claim that points at a figure and embeds a small
+// table and formatted chemistry inside its claim-text.
+const sampleFigrefClaimXML = `<claim id="CLM-00004" num="00004">
+  <claim-text>4. The cell of <claim-ref idref="CLM-00001">claim 1</claim-ref>, arranged as shown in <figref idref="DRAWINGS">FIG. <b>3</b>A</figref>, wherein the electrolyte comprises Li<sub>2</sub>S and the layers have the thicknesses
+    <tables id="TABLE-US-00001" num="00001"><table><tgroup cols="2"><tbody>
+      <row><entry>anode</entry><entry>10 μm</entry></row>
+    </tbody></tgroup></table></tables>
+    of <i>Table 1</i>.</claim-text>
+</claim>`
+
+func TestExtractClaimText_FigrefAndTable(t *testing.T) {
+	var claim Claim
+	if err := xml.Unmarshal([]byte(sampleFigrefClaimXML), &claim); err != nil {
+		t.Fatalf("unmarshal claim: %v", err)
+	}
+	text := claim.ExtractClaimText()
+	for _, want := range []string{
+		"The cell of claim 1,",
+		"as shown in FIG. 3A,",
+		"comprises Li2S and",
+		"anode 10 μm",
+		"of Table 1.",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("claim text missing %q:\n%s", want, text)
+		}
+	}
+}
+
 func TestExtractClaimText_Nil(t *testing.T) {
 	var claim *Claim
 	text := claim.ExtractClaimText()
@@ -658,3 +1132,481 @@ func BenchmarkExtractAllClaimsText(b *testing.B) {
 		_ = claims.ExtractAllClaimsText()
 	}
 }
+
+func TestGetPatentWithXML_SingleLookup(t *testing.T) {
+	var patentCalls, xmlCalls int
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/patent/applications/17248024":
+			patentCalls++
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"count":1,"patentFileWrapperDataBag":[{
+				"applicationNumberText":"17248024",
+				"grantDocumentMetaData":{"fileLocationURI":"%s/files/17248024.xml"}}]}`, server.URL)
+		case "/files/17248024.xml":
+			xmlCalls++
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write([]byte(sampleGrantXML))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	resp, doc, err := client.GetPatentWithXML(context.Background(), "17248024")
+	if err != nil {
+		t.Fatalf("GetPatentWithXML: %v", err)
+	}
+	if resp == nil || resp.PatentFileWrapperDataBag == nil || len(*resp.PatentFileWrapperDataBag) != 1 {
+		t.Fatalf("unexpected patent response: %+v", resp)
+	}
+	if doc == nil || doc.GetDocumentType() != DocumentTypeGrant {
+		t.Fatalf("expected parsed grant XML, got %+v", doc)
+	}
+	if got := doc.GetTitle(); got != "SYSTEM AND METHOD FOR ARTIFICIAL INTELLIGENCE" {
+		t.Errorf("title = %q", got)
+	}
+	if patentCalls != 1 {
+		t.Errorf("patent lookups = %d, want 1", patentCalls)
+	}
+	if xmlCalls != 1 {
+		t.Errorf("XML downloads = %d, want 1", xmlCalls)
+	}
+}
+
+func TestDownloadXMLWithProgress_RetriesAndReports(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if hits.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Length", fmt.Sprint(len(sampleGrantXML)))
+		_, _ = w.Write([]byte(sampleGrantXML))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.APIKey = "test"
+	cfg.RetryDelay = time.Millisecond
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	var last, total int64
+	doc, err := client.DownloadXMLWithProgress(context.Background(), server.URL+"/doc.xml", DocumentTypeGrant, func(done, size int64) {
+		last, total = done, size
+	})
+	if err != nil {
+		t.Fatalf("DownloadXMLWithProgress: %v", err)
+	}
+	if doc == nil || doc.GetTitle() == "" {
+		t.Error("expected a parsed grant with a title")
+	}
+	if hits.Load() != 2 {
+		t.Errorf("server hits = %d, want 2 (one retry after 503)", hits.Load())
+	}
+	if last != int64(len(sampleGrantXML)) || total != int64(len(sampleGrantXML)) {
+		t.Errorf("progress = %d/%d, want %d/%d", last, total, len(sampleGrantXML), len(sampleGrantXML))
+	}
+
+	// A 404 is not retried and surfaces as an *APIError.
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+	var apiErr *APIError
+	if _, err := client.DownloadXML(context.Background(), notFound.URL+"/missing.xml"); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("missing XML: err = %v, want a 404 APIError", err)
+	}
+}
+
+func TestDownloadXML_WithholdsAPIKeyFromForeignHost(t *testing.T) {
+	var gotKey string
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.Header.Get("X-API-Key")
+		_, _ = w.Write([]byte(sampleGrantXML))
+	}))
+	defer foreign.Close()
+
+	// BaseURL points elsewhere, so the XML host is neither configured nor uspto.gov.
+	cfg := DefaultConfig()
+	cfg.APIKey = "secret-key"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if _, err := client.DownloadXML(context.Background(), foreign.URL+"/doc.xml"); err != nil {
+		t.Fatalf("DownloadXML: %v", err)
+	}
+	if gotKey != "" {
+		t.Errorf("X-API-Key = %q sent to non-uspto host, want withheld", gotKey)
+	}
+
+	// The same server configured as BaseURL receives the key.
+	cfg.BaseURL = foreign.URL
+	client, err = NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if _, err := client.DownloadXML(context.Background(), foreign.URL+"/doc.xml"); err != nil {
+		t.Fatalf("DownloadXML: %v", err)
+	}
+	if gotKey != "secret-key" {
+		t.Errorf("X-API-Key = %q for configured host, want secret-key", gotKey)
+	}
+}
+
+// TestDownloadXML_WithholdsAPIKeyAcrossRedirect checks that a trusted XML
+// URL redirecting to another host does not pass the key on.
+func TestDownloadXML_WithholdsAPIKeyAcrossRedirect(t *testing.T) {
+	var foreignKey string
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		foreignKey = r.Header.Get("X-API-Key")
+		_, _ = w.Write([]byte(sampleGrantXML))
+	}))
+	defer foreign.Close()
+	var trustedKey string
+	trusted := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		trustedKey = r.Header.Get("X-API-Key")
+		http.Redirect(w, r, foreign.URL+"/doc.xml", http.StatusFound)
+	}))
+	defer trusted.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = trusted.URL
+	cfg.APIKey = "secret-key"
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if _, err := client.DownloadXML(context.Background(), trusted.URL+"/doc.xml"); err != nil {
+		t.Fatalf("DownloadXML: %v", err)
+	}
+	if trustedKey != "secret-key" {
+		t.Errorf("X-API-Key = %q at the configured host, want secret-key", trustedKey)
+	}
+	if foreignKey != "" {
+		t.Errorf("X-API-Key = %q forwarded to the redirect target, want withheld", foreignKey)
+	}
+}
+
+func TestAPIKeyAllowedFor(t *testing.T) {
+	c := &Client{config: &Config{BaseURL: "http://127.0.0.1:8080", OABaseURL: DefaultOABaseURL}}
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://api.uspto.gov/api/v1/datasets/products/files/x.xml", true},
+		{"https://data.USPTO.gov/x.xml", true},
+		{"https://uspto.gov/x.xml", true},
+		{"http://data.uspto.gov/x.xml", false},
+		{"http://127.0.0.1:8080/x.xml", true},
+		{"https://127.0.0.1:8080/x.xml", false},
+		{"http://127.0.0.1:9090/x.xml", false},
+		{"https://evil.example/x.xml", false},
+		{"https://uspto.gov.evil.example/x.xml", false},
+		{"https://notuspto.gov/x.xml", false},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.url)
+		if err != nil {
+			t.Fatalf("url.Parse(%q): %v", tt.url, err)
+		}
+		if got := c.apiKeyAllowedFor(u); got != tt.want {
+			t.Errorf("apiKeyAllowedFor(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestDrawingDescriptions(t *testing.T) {
+	doc, err := ParseGrantXML([]byte(sampleDrawingsDescriptionXML))
+	if err != nil {
+		t.Fatalf("ParseGrantXML: %v", err)
+	}
+	got := doc.GetDescription().DrawingDescriptions()
+	want := []string{
+		"FIG. 1 is a schematic illustration of a cell.",
+		"FIGS. 2A-C illustrate tubular anode designs.",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("DrawingDescriptions() = %q, want %d entries", got, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("DrawingDescriptions()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+	dod := doc.GetDescription().DescriptionOfDrawings
+	if dod.Paragraphs[0].Num != "0002" || dod.Paragraphs[0].ID != "p-0002" {
+		t.Errorf("paragraph attrs = %q/%q, want p-0002/0002", dod.Paragraphs[0].ID, dod.Paragraphs[0].Num)
+	}
+}
+
+func TestDrawingDescriptions_None(t *testing.T) {
+	doc, err := ParseGrantXML([]byte(sampleGrantXML))
+	if err != nil {
+		t.Fatalf("ParseGrantXML: %v", err)
+	}
+	if got := doc.GetDescription().DrawingDescriptions(); got != nil {
+		t.Errorf("DrawingDescriptions() = %q, want nil without the section", got)
+	}
+	var nilDesc *Description
+	if got := nilDesc.DrawingDescriptions(); got != nil {
+		t.Errorf("nil Description DrawingDescriptions() = %q, want nil", got)
+	}
+}
+
+func TestGetInventors(t *testing.T) {
+	doc, err := ParseXML([]byte(sampleGrantXML))
+	if err != nil {
+		t.Fatalf("ParseXML: %v", err)
+	}
+	inv := doc.GetInventors()
+	if len(inv) != 2 {
+		t.Fatalf("GetInventors() = %d inventors, want 2", len(inv))
+	}
+	if inv[0].FirstName != "Jane A." || inv[0].LastName != "Smith" || inv[0].InventorNameText != "Jane A. Smith" {
+		t.Errorf("inventor 1 name = %+v", inv[0])
+	}
+	if len(inv[0].CorrespondenceAddressBag) != 1 {
+		t.Fatalf("inventor 1 residence = %+v", inv[0].CorrespondenceAddressBag)
+	}
+	if a := inv[0].CorrespondenceAddressBag[0]; a.CityName != "Austin" || a.GeographicRegionCode != "TX" || a.CountryCode != "US" {
+		t.Errorf("inventor 1 residence = %+v", a)
+	}
+	if inv[1].LastName != "Müller" || inv[1].CountryCode != "DE" {
+		t.Errorf("inventor 2 = %+v", inv[1])
+	}
+
+	legacy, err := ParseXML([]byte(sampleLegacyPartiesGrantXML))
+	if err != nil {
+		t.Fatalf("ParseXML legacy: %v", err)
+	}
+	inv = legacy.GetInventors()
+	if len(inv) != 1 || inv[0].InventorNameText != "John Doe" || inv[0].CountryCode != "US" {
+		t.Errorf("legacy GetInventors() = %+v, want John Doe (US)", inv)
+	}
+
+	var nilDoc *XMLDocument
+	if nilDoc.GetInventors() != nil {
+		t.Error("nil document should have no inventors")
+	}
+}
+
+func TestGetClassifications(t *testing.T) {
+	doc, err := ParseXML([]byte(sampleGrantXML))
+	if err != nil {
+		t.Fatalf("ParseXML: %v", err)
+	}
+	if got, want := doc.GetCPCClassifications(), []string{"G06N   3/08", "A61L2101/32"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetCPCClassifications() = %q, want %q", got, want)
+	}
+	if got, want := doc.GetUSPCClassifications(), []string{"705/7", "424/185.1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetUSPCClassifications() = %q, want %q", got, want)
+	}
+
+	legacy, _ := ParseXML([]byte(sampleLegacyPartiesGrantXML))
+	if legacy.GetCPCClassifications() != nil || legacy.GetUSPCClassifications() != nil {
+		t.Error("document without classifications should return nil")
+	}
+}
+
+func TestGetCitations(t *testing.T) {
+	doc, err := ParseXML([]byte(sampleGrantXML))
+	if err != nil {
+		t.Fatalf("ParseXML: %v", err)
+	}
+	want := []CitedReference{
+		{Country: "US", DocNumber: "9876543", Kind: "B1", Name: "Lee et al.", Date: "20180102", Category: "cited by examiner"},
+		{NPLText: "Goodfellow et al., Deep Learning, MIT Press, 2016.", Category: "cited by applicant"},
+	}
+	got := doc.GetCitations()
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("GetCitations() = %+v, want %+v", got, want)
+	}
+	if !got[0].IsPatent() || !got[0].CitedByExaminer() || got[1].IsPatent() || got[1].CitedByExaminer() {
+		t.Errorf("IsPatent/CitedByExaminer wrong for %+v", got)
+	}
+
+	// Pre-4.2 documents nest <citation> under <references-cited>.
+	legacy, _ := ParseXML([]byte(sampleLegacyPartiesGrantXML))
+	if got := legacy.GetCitations(); len(got) != 1 || got[0].DocNumber != "4123456" || got[0].Category != "cited by other" {
+		t.Errorf("legacy GetCitations() = %+v", got)
+	}
+
+	app, _ := ParseXML([]byte(sampleApplicationXML))
+	if app.GetCitations() != nil {
+		t.Error("application should have no citations")
+	}
+}
+
+func TestGetAssignees(t *testing.T) {
+	tests := []struct {
+		name string
+		xml  string
+		want []Assignee
+	}{
+		{
+			name: "assignees block",
+			xml:  sampleGrantXML,
+			want: []Assignee{{Name: "Example Holdings LLC", City: "Wilmington", GeographicRegion: "DE", CountryName: "US"}},
+		},
+		{
+			// DTD 4.2 assignee without an addressbook wrapper.
+			name: "inline assignee",
+			xml:  sampleLegacyPartiesGrantXML,
+			want: []Assignee{{Name: "Acme Widget Corp.", City: "Chicago", GeographicRegion: "IL", PostalCode: "60601", CountryName: "US"}},
+		},
+		{
+			// Without <assignees>, the us-applicant with authority category
+			// "assignee" is used.
+			name: "applicant fallback",
+			xml:  sampleGrantXML[:strings.Index(sampleGrantXML, "<assignees>")] + sampleGrantXML[strings.Index(sampleGrantXML, "</assignees>")+len("</assignees>"):],
+			want: []Assignee{{Name: "Example Robotics, Inc.", City: "Austin", GeographicRegion: "TX", CountryName: "US"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseXML([]byte(tt.xml))
+			if err != nil {
+				t.Fatalf("ParseXML: %v", err)
+			}
+			if got := doc.GetAssignees(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetAssignees() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	var nilDoc *XMLDocument
+	if nilDoc.GetAssignees() != nil {
+		t.Error("nil document should have no assignees")
+	}
+}
+
+func TestGetFigures(t *testing.T) {
+	doc, err := ParseXML(readFixture(t, "grant_us11646472b2_17248024.xml"))
+	if err != nil {
+		t.Fatalf("ParseXML: %v", err)
+	}
+	figures := doc.GetFigures()
+	if len(figures) != 7 {
+		t.Fatalf("GetFigures() returned %d figures, want 7", len(figures))
+	}
+	want := Figure{
+		ID:  "Fig-EMI-D00001",
+		Num: "00001",
+		Img: Image{ID: "EMI-D00001", He: "227.84mm", Wi: "70.78mm", File: "US11646472-20230509-D00001.TIF", Alt: "embedded image", ImgContent: "drawing", ImgFormat: "tif"},
+	}
+	if figures[1] != want {
+		t.Errorf("GetFigures()[1] = %+v, want %+v", figures[1], want)
+	}
+
+	images := doc.GetFigureImages()
+	if len(images) != 7 || images[6].File != "US11646472-20230509-D00006.TIF" || images[6].Alt != "embedded image" {
+		t.Errorf("GetFigureImages() = %+v", images)
+	}
+
+	app, _ := ParseXML([]byte(sampleApplicationXML))
+	if app.GetFigures() != nil || app.GetFigureImages() != nil {
+		t.Error("application without drawings should have no figures")
+	}
+}
+
+func TestLoadXMLFile(t *testing.T) {
+	doc, err := LoadXMLFile("testdata/grant_us11646472b2_17248024.xml")
+	if err != nil {
+		t.Fatalf("LoadXMLFile: %v", err)
+	}
+	if doc.GetDocumentType() != DocumentTypeGrant || doc.GetTitle() == "" {
+		t.Errorf("LoadXMLFile returned type %v, title %q", doc.GetDocumentType(), doc.GetTitle())
+	}
+
+	dir := t.TempDir()
+	if _, err := LoadXMLFile(filepath.Join(dir, "missing.xml")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("missing file: err = %v, want fs.ErrNotExist", err)
+	}
+
+	other := filepath.Join(dir, "other.xml")
+	if err := os.WriteFile(other, []byte(`<?xml version="1.0"?><us-patent-assignment/>`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = LoadXMLFile(other)
+	if err == nil || !strings.Contains(err.Error(), other) || !strings.Contains(err.Error(), "unrecognized XML document type") {
+		t.Errorf("wrong document type: err = %v", err)
+	}
+}
+
+func TestClaimTransitionType(t *testing.T) {
+	tests := []struct {
+		text string
+		want TransitionType
+	}{
+		{"1. A widget comprising: a base; and a lever.", TransitionComprising},
+		{"2. The widget of claim 1, wherein the base comprises steel.", TransitionComprising},
+		{"3. A composition consisting of water and salt.", TransitionConsistingOf},
+		{"4. A composition consisting essentially of A and B, wherein A comprising C.", TransitionConsistingEssentiallyOf},
+		{"5. An alloy that Consists Essentially Of iron.", TransitionConsistingEssentiallyOf},
+		{"6. A kit consisting of a box, the box comprising a lid.", TransitionConsistingOf},
+		{"7. A device having a housing and including a sensor.", TransitionUnknown},
+	}
+	for _, tt := range tests {
+		claim := &Claim{ClaimText: []ClaimText{{Text: tt.text}}}
+		if got := claim.TransitionType(); got != tt.want {
+			t.Errorf("TransitionType(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+
+	var nilClaim *Claim
+	if got := nilClaim.TransitionType(); got != TransitionUnknown {
+		t.Errorf("nil claim TransitionType() = %v, want unknown", got)
+	}
+}
+
+func TestFullTextAnnotated(t *testing.T) {
+	doc, err := ParseXML([]byte(sampleGrantXML))
+	if err != nil {
+		t.Fatalf("ParseXML: %v", err)
+	}
+	segments := doc.FullTextAnnotated()
+
+	var got []string
+	for _, s := range segments {
+		got = append(got, s.Section+" "+s.ID)
+	}
+	want := []string{
+		"abstract 0001", "abstract 0002",
+		"description 0003", "description 0004",
+		"claims 1", "claims 2", "claims 3",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("segments = %v, want %v", got, want)
+	}
+	if s := segments[3]; s.Text != "Traditional neural networks face computational challenges." {
+		t.Errorf("description segment text = %q", s.Text)
+	}
+	if s := segments[4]; !strings.HasPrefix(s.Text, "1. A system comprising: a processor; and memory") {
+		t.Errorf("claim segment text = %q", s.Text)
+	}
+
+	// Brief-description-of-drawings paragraphs slot in by paragraph number.
+	drawings, err := ParseXML([]byte(sampleDrawingsDescriptionXML))
+	if err != nil {
+		t.Fatalf("ParseXML: %v", err)
+	}
+	got = nil
+	for _, s := range drawings.FullTextAnnotated() {
+		got = append(got, s.ID)
+	}
+	if want := []string{"0001", "0002", "0003", "0004"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("drawings description paragraph order = %v, want %v", got, want)
+	}
+}